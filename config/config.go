@@ -1,15 +1,69 @@
 package config
 
+import (
+	"errors"
+	"reflect"
+)
+
 type Option func(*MockConfig)
 
+// ErrUnstubbedCall is the error of calls that do not match any stubbing.
+var ErrUnstubbedCall = errors.New("unstubbed call")
+
+// DefaultAnswer computes return values for a call that does not match any stubbing.
+// Returning an error fails the test. Errors that wrap ErrUnstubbedCall are reported
+// together with the stubbings of the called method.
+type DefaultAnswer func(data *DefaultAnswerData) ([]any, error)
+
+// DefaultAnswerData describes a call that does not match any stubbing.
+type DefaultAnswerData struct {
+	Method reflect.Method
+	Args   []any
+	// Mock is the mock object the method was called on. It is nil for generated mocks.
+	Mock any
+}
+
+// ExhaustionPolicy defines how a stubbing answers once all of its consecutive answers were used.
+type ExhaustionPolicy int
+
+const (
+	// RepeatLastAnswer repeats the last answer.
+	RepeatLastAnswer ExhaustionPolicy = iota
+	// CycleAnswers starts over from the first answer.
+	CycleAnswers
+	// FallbackToDefaults returns default values, like for an unstubbed call.
+	FallbackToDefaults
+	// FailWhenExhausted fails the test.
+	FailWhenExhausted
+)
+
 type MockConfig struct {
-	PrintStackTrace bool
-	StrictVerify    bool
+	Name             string
+	PrintStackTrace  bool
+	StrictVerify     bool
+	DeepStubs        bool
+	DefaultAnswer    DefaultAnswer
+	ExhaustionPolicy ExhaustionPolicy
+	LastStubbingWins bool
+	Equality         map[reflect.Type]func(a, b any) bool
+}
+
+// WithName returns an option that sets the name that identifies a mock in error reports.
+func WithName(name string) Option {
+	return func(cfg *MockConfig) {
+		cfg.Name = name
+	}
 }
 
 func NewConfig() *MockConfig {
 	return &MockConfig{
-		PrintStackTrace: true,
-		StrictVerify:    false,
+		Name:             "",
+		PrintStackTrace:  true,
+		StrictVerify:     false,
+		DeepStubs:        false,
+		DefaultAnswer:    nil,
+		ExhaustionPolicy: RepeatLastAnswer,
+		LastStubbingWins: false,
+		Equality:         make(map[reflect.Type]func(a, b any) bool),
 	}
 }
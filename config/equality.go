@@ -0,0 +1,37 @@
+package config
+
+import (
+	"reflect"
+	"time"
+)
+
+// EqualityOption configures how values are compared by the EqualWith matcher.
+type EqualityOption func(*EqualityConfig)
+
+// EqualityConfig defines the differences that are ignored when values are compared.
+type EqualityConfig struct {
+	// IgnoredFields are dot-separated paths of struct fields that are not compared.
+	// Elements of slices, arrays and maps are addressed by the path of the container.
+	IgnoredFields []string
+	// IgnoreUnexported skips unexported struct fields.
+	IgnoreUnexported bool
+	// NilEqualsEmpty treats nil slices and maps as equal to empty ones.
+	NilEqualsEmpty bool
+	// FloatEpsilon is the maximum difference between floats that are considered equal.
+	FloatEpsilon float64
+	// TimeTolerance is the maximum difference between times that are considered equal.
+	TimeTolerance time.Duration
+	// Comparers are custom comparison functions for values of specific types.
+	Comparers map[reflect.Type]func(a, b any) bool
+}
+
+func NewEqualityConfig() *EqualityConfig {
+	return &EqualityConfig{
+		IgnoredFields:    make([]string, 0),
+		IgnoreUnexported: false,
+		NilEqualsEmpty:   false,
+		FloatEpsilon:     0,
+		TimeTolerance:    0,
+		Comparers:        make(map[reflect.Type]func(a, b any) bool),
+	}
+}
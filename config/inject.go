@@ -0,0 +1,33 @@
+package config
+
+// InjectOption configures a single InjectMocks call.
+// Every Option is an InjectOption that is applied to all injected mocks.
+type InjectOption interface {
+	ApplyInject(cfg *InjectConfig)
+}
+
+// InjectConfigOption is an InjectOption that configures the injection itself, rather than the injected mocks.
+type InjectConfigOption func(*InjectConfig)
+
+// InjectConfig defines how InjectMocks populates fields of a struct.
+type InjectConfig struct {
+	// Unexported makes unexported fields populated as well.
+	Unexported bool
+	// MockOptions are applied to every injected mock.
+	MockOptions []Option
+}
+
+func NewInjectConfig() *InjectConfig {
+	return &InjectConfig{
+		Unexported:  false,
+		MockOptions: make([]Option, 0),
+	}
+}
+
+func (o InjectConfigOption) ApplyInject(cfg *InjectConfig) {
+	o(cfg)
+}
+
+func (o Option) ApplyInject(cfg *InjectConfig) {
+	cfg.MockOptions = append(cfg.MockOptions, o)
+}
@@ -0,0 +1,12 @@
+package matchers
+
+// InjectedMocks holds mocks created by InjectMocks.
+// Mocks are identified by the name of the struct field they were injected into,
+// or by the name from the `mockio:"name=..."` struct tag.
+type InjectedMocks interface {
+	// Get returns the mock with the given name.
+	Get(name string) (any, bool)
+
+	// Names returns names of all injected mocks in field declaration order.
+	Names() []string
+}
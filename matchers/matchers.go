@@ -1,11 +1,36 @@
 package matchers
 
+import "reflect"
+
 // Answer is a type alias for a function that can be used as a return value for mock function calls.
 // This function takes a variable number of interface{} arguments and returns a slice of interface{} values.
 // Each value in the returned slice corresponds to a return value for the mock function call.
 // This type can be used to provide dynamic return values based on the input arguments passed to the mock function call.
 type Answer = func(args []any) []any
 
+// Invocation describes a call of a mocked method that is being answered.
+type Invocation struct {
+	// MethodName is the name of the called method. Calls of func mocks are named "Call".
+	MethodName string
+	// MethodType is the called method.
+	MethodType reflect.Method
+	// Args are the arguments of the call. Variadic arguments are flattened.
+	Args []any
+	// StubCallIndex is the zero-based index of the call among calls answered by the stubbing.
+	StubCallIndex int
+	// MethodCallIndex is the zero-based index of the call among all calls of the method on the mock.
+	MethodCallIndex int
+	// Mock is the mock object the method was called on. It is nil for generated mocks.
+	Mock any
+	// GoroutineID is the id of the goroutine that made the call.
+	GoroutineID int64
+	// StubbedAt is the location where the stubbing was defined.
+	StubbedAt string
+}
+
+// InvocationAnswer is like Answer, but it receives the description of the call instead of its arguments only.
+type InvocationAnswer = func(inv Invocation) []any
+
 // Matcher interface represents an object capable of matching method calls to specific criteria.
 //
 // A Matcher should implement the Match method, which takes a MethodCall and an actual parameter, and returns true
@@ -25,3 +50,11 @@ type Matcher[T any] interface {
 	// The allArgs parameter represents all the arguments that were passed to a method.
 	Match(allArgs []any, actual T) bool
 }
+
+// MismatchDescriber can be implemented by matchers to explain why a value does not match,
+// for example by naming the field of a struct that differs.
+// The explanation is included in error reports.
+type MismatchDescriber interface {
+	// DescribeMismatch returns the reason why actual does not match, or an empty string if it matches.
+	DescribeMismatch(allArgs []any, actual any) string
+}
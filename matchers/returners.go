@@ -15,6 +15,44 @@ type ReturnerSingle[T any] interface {
 	// called with one argument. The function must take a variable number of
 	// arguments of type interface{} and return a value of type T.
 	ThenAnswer(func(args []any) T) ReturnerSingle[T]
+	// ThenAnswerInvocation is like ThenAnswer, but the function receives the description of the call,
+	// including its index and the mock it was made on.
+	ThenAnswerInvocation(func(inv Invocation) T) ReturnerSingle[T]
+	// ThenCallRealMethod delegates the call to the real implementation wrapped by a spy.
+	ThenCallRealMethod() ReturnerSingle[T]
+	// ThenPanic makes the mock function panic with the given value.
+	ThenPanic(value any) ReturnerSingle[T]
+	// ThenDo sets a function that will be called with the actual arguments of the mock function.
+	// The function must have the same signature as the mock function.
+	ThenDo(fn any) ReturnerSingle[T]
+	// ThenEmit makes the mocked function return a fresh channel with the given values, closed after the last value.
+	ThenEmit(values ...any) ReturnerSingle[T]
+	// ThenEmitAsync makes the mocked function return a fresh channel fed by a goroutine, closed after the last value.
+	ThenEmitAsync(values ...any) ReturnerSingle[T]
+	// ThenYield makes the mocked function return an iterator over the given values.
+	ThenYield(values ...any) ReturnerSingle[T]
+	// ThenSetArg sets the value pointed to by the argument with the given index.
+	// It applies to the answer that follows it.
+	ThenSetArg(index int, value any) ReturnerSingle[T]
+	// ThenCopyToArg copies data to the byte slice argument with the given index.
+	// It applies to the answer that follows it.
+	ThenCopyToArg(index int, data []byte) ReturnerSingle[T]
+	// ThenSetVariadicArgs sets the values pointed to by the variadic arguments.
+	// It applies to the answer that follows it.
+	ThenSetVariadicArgs(values ...any) ReturnerSingle[T]
+	// RepeatLast makes the stubbing repeat its last answer once all answers were used. This is the default.
+	RepeatLast() ReturnerSingle[T]
+	// Cycle makes the stubbing start over from its first answer once all answers were used.
+	Cycle() ReturnerSingle[T]
+	// FallbackToDefaults makes the stubbing return default values once all answers were used.
+	FallbackToDefaults() ReturnerSingle[T]
+	// Only makes calls fail the test once all answers of the stubbing were used.
+	Only() ReturnerSingle[T]
+	// Times limits the number of calls answered by the stubbing.
+	// Once the limit is reached, calls are matched against the next stubbings of the method.
+	Times(n int) ReturnerSingle[T]
+	// Override replaces existing stubbings of the method with the same matchers.
+	Override() ReturnerSingle[T]
 }
 
 // ReturnerDouble is an interface that provides methods to define the returned value and error of a mock function with a single argument.
@@ -26,6 +64,140 @@ type ReturnerDouble[A any, B any] interface {
 	ThenReturn(a A, b B) ReturnerDouble[A, B]
 	// ThenAnswer sets the return value and error of the mocked function to the value and error returned by the provided function respectively.
 	ThenAnswer(func(args []any) (A, B)) ReturnerDouble[A, B]
+	// ThenAnswerInvocation is like ThenAnswer, but the function receives the description of the call,
+	// including its index and the mock it was made on.
+	ThenAnswerInvocation(func(inv Invocation) (A, B)) ReturnerDouble[A, B]
+	// ThenCallRealMethod delegates the call to the real implementation wrapped by a spy.
+	ThenCallRealMethod() ReturnerDouble[A, B]
+	// ThenPanic makes the mocked function panic with the given value.
+	ThenPanic(value any) ReturnerDouble[A, B]
+	// ThenDo sets a function that will be called with the actual arguments of the mocked function.
+	// The function must have the same signature as the mocked function.
+	ThenDo(fn any) ReturnerDouble[A, B]
+	// ThenEmit makes the mocked function return a fresh channel with the given values, closed after the last value.
+	ThenEmit(values ...any) ReturnerDouble[A, B]
+	// ThenEmitAsync makes the mocked function return a fresh channel fed by a goroutine, closed after the last value.
+	ThenEmitAsync(values ...any) ReturnerDouble[A, B]
+	// ThenYield makes the mocked function return an iterator over the given values.
+	ThenYield(values ...any) ReturnerDouble[A, B]
+	// ThenSetArg sets the value pointed to by the argument with the given index.
+	// It applies to the answer that follows it.
+	ThenSetArg(index int, value any) ReturnerDouble[A, B]
+	// ThenCopyToArg copies data to the byte slice argument with the given index.
+	// It applies to the answer that follows it.
+	ThenCopyToArg(index int, data []byte) ReturnerDouble[A, B]
+	// ThenSetVariadicArgs sets the values pointed to by the variadic arguments.
+	// It applies to the answer that follows it.
+	ThenSetVariadicArgs(values ...any) ReturnerDouble[A, B]
+	// RepeatLast makes the stubbing repeat its last answer once all answers were used. This is the default.
+	RepeatLast() ReturnerDouble[A, B]
+	// Cycle makes the stubbing start over from its first answer once all answers were used.
+	Cycle() ReturnerDouble[A, B]
+	// FallbackToDefaults makes the stubbing return default values once all answers were used.
+	FallbackToDefaults() ReturnerDouble[A, B]
+	// Only makes calls fail the test once all answers of the stubbing were used.
+	Only() ReturnerDouble[A, B]
+	// Times limits the number of calls answered by the stubbing.
+	// Once the limit is reached, calls are matched against the next stubbings of the method.
+	Times(n int) ReturnerDouble[A, B]
+	// Override replaces existing stubbings of the method with the same matchers.
+	Override() ReturnerDouble[A, B]
+}
+
+// ReturnerTriple is an interface that provides methods to define three return values of a mock function.
+// It is returned by the WhenTriple function.
+type ReturnerTriple[A any, B any, C any] interface {
+	Returner
+	// ThenReturn sets the return values of the mocked function.
+	ThenReturn(a A, b B, c C) ReturnerTriple[A, B, C]
+	// ThenAnswer sets the return values of the mocked function to the values returned by the provided function.
+	ThenAnswer(func(args []any) (A, B, C)) ReturnerTriple[A, B, C]
+	// ThenAnswerInvocation is like ThenAnswer, but the function receives the description of the call,
+	// including its index and the mock it was made on.
+	ThenAnswerInvocation(func(inv Invocation) (A, B, C)) ReturnerTriple[A, B, C]
+	// ThenCallRealMethod delegates the call to the real implementation wrapped by a spy.
+	ThenCallRealMethod() ReturnerTriple[A, B, C]
+	// ThenPanic makes the mocked function panic with the given value.
+	ThenPanic(value any) ReturnerTriple[A, B, C]
+	// ThenDo sets a function that will be called with the actual arguments of the mocked function.
+	// The function must have the same signature as the mocked function.
+	ThenDo(fn any) ReturnerTriple[A, B, C]
+	// ThenEmit makes the mocked function return a fresh channel with the given values, closed after the last value.
+	ThenEmit(values ...any) ReturnerTriple[A, B, C]
+	// ThenEmitAsync makes the mocked function return a fresh channel fed by a goroutine, closed after the last value.
+	ThenEmitAsync(values ...any) ReturnerTriple[A, B, C]
+	// ThenYield makes the mocked function return an iterator over the given values.
+	ThenYield(values ...any) ReturnerTriple[A, B, C]
+	// ThenSetArg sets the value pointed to by the argument with the given index.
+	// It applies to the answer that follows it.
+	ThenSetArg(index int, value any) ReturnerTriple[A, B, C]
+	// ThenCopyToArg copies data to the byte slice argument with the given index.
+	// It applies to the answer that follows it.
+	ThenCopyToArg(index int, data []byte) ReturnerTriple[A, B, C]
+	// ThenSetVariadicArgs sets the values pointed to by the variadic arguments.
+	// It applies to the answer that follows it.
+	ThenSetVariadicArgs(values ...any) ReturnerTriple[A, B, C]
+	// RepeatLast makes the stubbing repeat its last answer once all answers were used. This is the default.
+	RepeatLast() ReturnerTriple[A, B, C]
+	// Cycle makes the stubbing start over from its first answer once all answers were used.
+	Cycle() ReturnerTriple[A, B, C]
+	// FallbackToDefaults makes the stubbing return default values once all answers were used.
+	FallbackToDefaults() ReturnerTriple[A, B, C]
+	// Only makes calls fail the test once all answers of the stubbing were used.
+	Only() ReturnerTriple[A, B, C]
+	// Times limits the number of calls answered by the stubbing.
+	// Once the limit is reached, calls are matched against the next stubbings of the method.
+	Times(n int) ReturnerTriple[A, B, C]
+	// Override replaces existing stubbings of the method with the same matchers.
+	Override() ReturnerTriple[A, B, C]
+}
+
+// ReturnerQuad is an interface that provides methods to define four return values of a mock function.
+// It is returned by the WhenQuad function.
+type ReturnerQuad[A any, B any, C any, D any] interface {
+	Returner
+	// ThenReturn sets the return values of the mocked function.
+	ThenReturn(a A, b B, c C, d D) ReturnerQuad[A, B, C, D]
+	// ThenAnswer sets the return values of the mocked function to the values returned by the provided function.
+	ThenAnswer(func(args []any) (A, B, C, D)) ReturnerQuad[A, B, C, D]
+	// ThenAnswerInvocation is like ThenAnswer, but the function receives the description of the call,
+	// including its index and the mock it was made on.
+	ThenAnswerInvocation(func(inv Invocation) (A, B, C, D)) ReturnerQuad[A, B, C, D]
+	// ThenCallRealMethod delegates the call to the real implementation wrapped by a spy.
+	ThenCallRealMethod() ReturnerQuad[A, B, C, D]
+	// ThenPanic makes the mocked function panic with the given value.
+	ThenPanic(value any) ReturnerQuad[A, B, C, D]
+	// ThenDo sets a function that will be called with the actual arguments of the mocked function.
+	// The function must have the same signature as the mocked function.
+	ThenDo(fn any) ReturnerQuad[A, B, C, D]
+	// ThenEmit makes the mocked function return a fresh channel with the given values, closed after the last value.
+	ThenEmit(values ...any) ReturnerQuad[A, B, C, D]
+	// ThenEmitAsync makes the mocked function return a fresh channel fed by a goroutine, closed after the last value.
+	ThenEmitAsync(values ...any) ReturnerQuad[A, B, C, D]
+	// ThenYield makes the mocked function return an iterator over the given values.
+	ThenYield(values ...any) ReturnerQuad[A, B, C, D]
+	// ThenSetArg sets the value pointed to by the argument with the given index.
+	// It applies to the answer that follows it.
+	ThenSetArg(index int, value any) ReturnerQuad[A, B, C, D]
+	// ThenCopyToArg copies data to the byte slice argument with the given index.
+	// It applies to the answer that follows it.
+	ThenCopyToArg(index int, data []byte) ReturnerQuad[A, B, C, D]
+	// ThenSetVariadicArgs sets the values pointed to by the variadic arguments.
+	// It applies to the answer that follows it.
+	ThenSetVariadicArgs(values ...any) ReturnerQuad[A, B, C, D]
+	// RepeatLast makes the stubbing repeat its last answer once all answers were used. This is the default.
+	RepeatLast() ReturnerQuad[A, B, C, D]
+	// Cycle makes the stubbing start over from its first answer once all answers were used.
+	Cycle() ReturnerQuad[A, B, C, D]
+	// FallbackToDefaults makes the stubbing return default values once all answers were used.
+	FallbackToDefaults() ReturnerQuad[A, B, C, D]
+	// Only makes calls fail the test once all answers of the stubbing were used.
+	Only() ReturnerQuad[A, B, C, D]
+	// Times limits the number of calls answered by the stubbing.
+	// Once the limit is reached, calls are matched against the next stubbings of the method.
+	Times(n int) ReturnerQuad[A, B, C, D]
+	// Override replaces existing stubbings of the method with the same matchers.
+	Override() ReturnerQuad[A, B, C, D]
 }
 
 // ReturnerAll is a type that defines the methods for returning and answering values for
@@ -43,4 +215,126 @@ type ReturnerAll interface {
 	// This method can be called multiple times to set up different answer functions
 	// for different calls to the same method with the same arguments.
 	ThenAnswer(answer Answer) ReturnerAll
+
+	// ThenAnswerInvocation is like ThenAnswer, but the function receives the description of the call
+	// instead of its arguments only. It is useful for answers that depend on the index of the call:
+	//
+	//	When(mock.Fetch(AnyInt())).ThenAnswerInvocation(func(inv Invocation) []any {
+	//		if inv.StubCallIndex < 2 {
+	//			return []any{nil, errBusy}
+	//		}
+	//		return []any{page, nil}
+	//	})
+	ThenAnswerInvocation(answer InvocationAnswer) ReturnerAll
+
+	// ThenCallRealMethod delegates the call to the real implementation wrapped by a spy.
+	// It can be mixed with other answers, so that only some of the consecutive calls reach the real implementation.
+	// Using it on a mock that was not created with Spy results in an error.
+	ThenCallRealMethod() ReturnerAll
+
+	// ThenPanic makes the method call panic with the given value.
+	// The call is still recorded, so it can be verified after the panic is recovered.
+	// It can be mixed with other answers, so that only some of the consecutive calls panic.
+	ThenPanic(value any) ReturnerAll
+
+	// ThenDo sets a function that will be called with the actual arguments of the method call.
+	// Unlike ThenAnswer, the function must have exactly the same signature as the method being mocked,
+	// for example func(ctx context.Context, id string) (*User, error).
+	// The signature is checked when the stub is defined.
+	ThenDo(fn any) ReturnerAll
+
+	// ThenEmit makes the method return a fresh channel on every call, for example:
+	//
+	//	When(bus.Subscribe("orders")).ThenEmit(OrderCreated{ID: 1}, OrderCreated{ID: 2})
+	//
+	// The channel is filled with the values and closed after the last one, so ranging over it terminates.
+	// If the method also returns a channel of errors, like Watch() (<-chan Event, <-chan error),
+	// error values that are not assignable to the element type of the first channel are sent to the error channel.
+	// Other return values are zero values.
+	// The values are checked against the channel element types when the stub is defined.
+	ThenEmit(values ...any) ReturnerAll
+
+	// ThenEmitAsync works like ThenEmit, but the returned channels are unbuffered and fed by a goroutine,
+	// so each value is delivered only when the receiver is ready for it.
+	// The channels are closed after the last value.
+	// If the call has a context.Context argument, the goroutine stops and closes the channels when the context is done.
+	// The goroutine also stops at the end of the test, so channels that are not drained do not leak it.
+	// When the method returns an error channel, values and errors are sent in the given order,
+	// so receivers have to read both channels, for example in a select loop.
+	ThenEmitAsync(values ...any) ReturnerAll
+
+	// ThenYield makes the method return an iterator over the given values,
+	// for methods returning iter.Seq, iter.Seq2 or any function of the same shape.
+	// For two-value iterators the values are given in key-value pairs:
+	//
+	//	When(store.All()).ThenYield("a", 1, "b", 2)
+	//
+	// The iterator stops when the consumer breaks out of the loop.
+	// The values are checked against the iterator types when the stub is defined.
+	ThenYield(values ...any) ReturnerAll
+
+	// ThenSetArg sets the value pointed to by the argument with the given index, for example:
+	//
+	//	When(decoder.Decode(Any[*User]())).ThenSetArg(0, User{Name: "John"}).ThenReturn(nil)
+	//
+	// Variadic arguments are indexed by their position in the call, as if they were regular arguments.
+	// Argument setters apply to the answer that follows them, or return zero values if there is no such answer.
+	// Types are checked when the stub is defined, unless the argument is declared as an interface.
+	ThenSetArg(index int, value any) ReturnerAll
+
+	// ThenCopyToArg copies data to the byte slice argument with the given index, for example:
+	//
+	//	When(reader.Read(Any[[]byte]())).ThenCopyToArg(0, []byte("hello")).ThenReturn(5, nil)
+	//
+	// Like ThenSetArg, it applies to the answer that follows it.
+	ThenCopyToArg(index int, data []byte) ReturnerAll
+
+	// ThenSetVariadicArgs sets the values pointed to by the variadic arguments, for example:
+	//
+	//	When(row.Scan(Any[any](), Any[any]())).ThenSetVariadicArgs(1, "John").ThenReturn(nil)
+	//
+	// Like ThenSetArg, it applies to the answer that follows it.
+	ThenSetVariadicArgs(values ...any) ReturnerAll
+
+	// RepeatLast makes the stubbing repeat its last answer once all answers were used.
+	// This is the default, unless another policy is set with mockopts.WhenExhausted option.
+	RepeatLast() ReturnerAll
+
+	// Cycle makes the stubbing start over from its first answer once all answers were used:
+	//
+	//	When(mock.Foo()).ThenReturn(1).ThenReturn(2).Cycle() // 1, 2, 1, 2, ...
+	Cycle() ReturnerAll
+
+	// FallbackToDefaults makes the stubbing return default values once all answers were used,
+	// as if the call was not stubbed.
+	FallbackToDefaults() ReturnerAll
+
+	// Only makes calls fail the test once all answers of the stubbing were used:
+	//
+	//	When(mock.Foo()).ThenReturn(1).Only() // 1, then "stub exhausted after 1 calls"
+	Only() ReturnerAll
+
+	// Times limits the number of calls answered by the stubbing.
+	// Once the limit is reached, calls are matched against the next stubbings of the method,
+	// so that a general stubbing can be layered under a limited one:
+	//
+	//	When(mock.Get(AnyString())).ThenReturn(nil, errBusy).Times(2)
+	//	When(mock.Get(AnyString())).ThenReturn(user, nil)
+	Times(n int) ReturnerAll
+
+	// Override replaces existing stubbings of the method with the same matchers,
+	// for example a stubbing defined in a shared setup:
+	//
+	//	When(mock.Get("a")).ThenReturn("test").Override()
+	//
+	// Replaced stubbings are not verified.
+	Override() ReturnerAll
+}
+
+// Stubber defines an answer for the next call on a mock.
+// It is useful for stubbing methods without return values, that can not be passed to When.
+type Stubber[T any] interface {
+	// When makes the next call on the mock a stub definition.
+	// Arguments of the call are matched the same way as arguments in When.
+	When(mock T) T
 }
@@ -3,9 +3,11 @@ package mock
 import (
 	"context"
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/ovechkin-dm/mockio/v2/config"
 	"github.com/ovechkin-dm/mockio/v2/matchers"
@@ -37,8 +39,64 @@ import (
 //	   // Verify that the mock was called with the correct arguments
 //	   Verify(myMock, Times(1)).MyMethod(Any[string](), Any[int]())
 //	}
-func Mock[T any](ctrl *matchers.MockController) T {
-	return registry.Mock[T](ctrl)
+//
+// Options from mockopts package can be passed to override controller configuration for a single mock:
+//
+//	replica := Mock[Store](ctrl, mockopts.Name("replica"), mockopts.ReturnEmptyValues())
+func Mock[T any](ctrl *matchers.MockController, opts ...config.Option) T {
+	return registry.Mock[T](ctrl, opts...)
+}
+
+// Spy returns a mock object that wraps the provided real implementation of T.
+// Calls that match a stubbing are answered by the stubbing, while all other calls are
+// delegated to the real implementation. Every call is recorded, so it can be verified
+// and captured in the same way as calls on a regular mock.
+//
+// Keep in mind that a stubbing with exact values, like When(spy.Foo(10)), invokes the real
+// method once while the stubbing is being defined. Use matchers to avoid that.
+// Per-mock options can be passed in the same way as for Mock.
+//
+// Example usage:
+//
+//	func TestMyFunction(t *testing.T) {
+//	   ctrl := NewMockController(t)
+//
+//	   // Wrap real implementation
+//	   spy := Spy[MyInterface](ctrl, &myImpl{})
+//
+//	   // Override a single method, all other methods stay real
+//	   WhenDouble(spy.MyMethod(Any[string](), Any[int]())).ThenReturn("bar", nil)
+//
+//	   // Verify calls to the real methods
+//	   Verify(spy, Once()).MyOtherMethod()
+//	}
+func Spy[T any](ctrl *matchers.MockController, delegate T, opts ...config.Option) T {
+	return registry.Spy[T](ctrl, delegate, opts...)
+}
+
+// MockFunc returns a mock of the func type F.
+// The returned func can be stubbed and verified in the same way as a method of a mocked interface.
+// Per-mock options can be passed in the same way as for Mock.
+//
+// Example usage:
+//
+//	func TestMyFunction(t *testing.T) {
+//	   ctrl := NewMockController(t)
+//
+//	   // Create a mock func
+//	   fetch := MockFunc[func(ctx context.Context, id string) error](ctrl)
+//
+//	   // Set up a mock behavior
+//	   WhenSingle(fetch(AnyContext(), Any[string]())).ThenReturn(errNotFound)
+//
+//	   // Call the func
+//	   err := fetch(ctx, "id")
+//
+//	   // Verify that the func was called
+//	   Verify(fetch, Once())(AnyContext(), Exact("id"))
+//	}
+func MockFunc[F any](ctrl *matchers.MockController, opts ...config.Option) F {
+	return registry.MockFunc[F](ctrl, opts...)
 }
 
 // Any returns a mock value of type T that matches any value of type T.
@@ -175,19 +233,22 @@ func MapLen[K comparable, V any](value int) map[K]V {
 }
 
 // SliceContains returns matcher that matches any slice that contains specified values.
+// Values are compared with reflect.DeepEqual, or with custom equalities set with mockopts.WithEquality.
 // Example usage:
 //
 //	WhenSingle(myMock.MyMethod(SliceContains("foo", "bar"))).ThenReturn("baz")
 func SliceContains[T any](values ...T) []T {
 	desc := fmt.Sprintf("SliceContains(%v)", values)
-	m := registry.FunMatcher(desc, func(m []any, actual []T) bool {
-		amap := make(map[any]struct{})
-		for _, v := range actual {
-			amap[v] = struct{}{}
-		}
+	m := registry.EqualityMatcher(desc, func(eq registry.Equality, m []any, actual []T) bool {
 		for _, v := range values {
-			_, ok := amap[v]
-			if !ok {
+			found := false
+			for _, a := range actual {
+				if eq(v, a) {
+					found = true
+					break
+				}
+			}
+			if !found {
 				return false
 			}
 		}
@@ -281,6 +342,7 @@ func Exact[T comparable](value T) T {
 
 // Equal returns a matcher that matches values of type T that are equal via reflect.DeepEqual to the provided value.
 // The value passed to Equal must be of the exact same type as values of type T.
+// Custom equalities set with mockopts.WithEquality are used instead of reflect.DeepEqual for values of their types.
 //
 // Example usage:
 //
@@ -290,17 +352,41 @@ func Exact[T comparable](value T) T {
 //	// Set up a mock behavior for a method that takes an integer argument exactly equal to 42
 //	WhenSingle(myMock.MyOtherMethod(Equal(42))).ThenReturn("baz")
 func Equal[T any](value T) T {
-	desc := fmt.Sprintf("Equal(%v)", value)
-	m := registry.FunMatcher[T](desc, func(m []any, actual T) bool {
-		return reflect.DeepEqual(value, actual)
-	})
+	m := registry.EqualMatcher(value)
 	registry.AddMatcher(m)
 	var t T
 	return t
 }
 
+// EqualWith returns a matcher that matches values of type T that are deeply equal to the provided value,
+// except for the differences ignored by the options:
+//
+//	// Ignore generated fields and compare timestamps with a tolerance
+//	WhenSingle(repo.Save(EqualWith(user,
+//		mockopts.IgnoreFields("ID"),
+//		mockopts.TimeTolerance(time.Second),
+//	))).ThenReturn(true)
+//
+// Unlike Equal, time.Time values are compared with time.Time.Equal.
+// Unknown ignored fields are reported when the stub is defined.
+func EqualWith[T any](value T, opts ...config.EqualityOption) T {
+	var t T
+	cfg := config.NewEqualityConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	m, err := registry.EqualWithMatcher(value, cfg)
+	if err != nil {
+		registry.AddInvalidMatcher(fmt.Sprintf("EqualWith(%v)", value), err)
+		return t
+	}
+	registry.AddMatcher(m)
+	return t
+}
+
 // NotEqual returns a matcher that matches values of type T that are not equal via reflect.DeepEqual to the provided value.
 // The value passed to NotEqual must be of the exact same type as values of type T.
+// Custom equalities set with mockopts.WithEquality are used instead of reflect.DeepEqual for values of their types.
 //
 // Example usage:
 //
@@ -311,8 +397,8 @@ func Equal[T any](value T) T {
 //	WhenSingle(myMock.MyOtherMethod(NotEqual(42))).ThenReturn("baz")
 func NotEqual[T any](value T) T {
 	desc := fmt.Sprintf("NotEqual(%v)", value)
-	m := registry.FunMatcher[T](desc, func(m []any, actual T) bool {
-		return !reflect.DeepEqual(value, actual)
+	m := registry.EqualityMatcher[T](desc, func(eq registry.Equality, m []any, actual T) bool {
+		return !eq(value, actual)
 	})
 	registry.AddMatcher(m)
 	var t T
@@ -321,6 +407,7 @@ func NotEqual[T any](value T) T {
 
 // OneOf returns a matcher that matches at least one of values of type T that are equal via reflect.DeepEqual to the provided value.
 // The value passed to OneOf must be of the exact same type as values of type T.
+// Custom equalities set with mockopts.WithEquality are used instead of reflect.DeepEqual for values of their types.
 //
 // Example usage:
 //
@@ -336,9 +423,9 @@ func OneOf[T any](values ...T) T {
 	}
 
 	desc := fmt.Sprintf("OneOf(%s)", strings.Join(vs, ","))
-	m := registry.FunMatcher[T](desc, func(args []any, t T) bool {
+	m := registry.EqualityMatcher[T](desc, func(eq registry.Equality, args []any, t T) bool {
 		for i := range values {
-			if reflect.DeepEqual(values[i], t) {
+			if eq(values[i], t) {
 				return true
 			}
 		}
@@ -349,16 +436,254 @@ func OneOf[T any](values ...T) T {
 	return t
 }
 
+// Ordered is a constraint that permits any ordered type: integers, floats and strings.
+// It is the same as cmp.Ordered, which is not available before Go 1.21.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// Gt returns a matcher that matches values greater than the provided value.
+// Example usage:
+//
+//	WhenSingle(myMock.Withdraw(Gt(0))).ThenReturn(true)
+func Gt[T Ordered](value T) T {
+	return orderedMatcher(fmt.Sprintf("Gt(%v)", value), func(actual T) bool {
+		return actual > value
+	})
+}
+
+// Gte returns a matcher that matches values greater than or equal to the provided value.
+// Example usage:
+//
+//	WhenSingle(myMock.SetAge(Gte(18))).ThenReturn(true)
+func Gte[T Ordered](value T) T {
+	return orderedMatcher(fmt.Sprintf("Gte(%v)", value), func(actual T) bool {
+		return actual >= value
+	})
+}
+
+// Lt returns a matcher that matches values less than the provided value.
+// Example usage:
+//
+//	WhenSingle(myMock.Withdraw(Lt(100))).ThenReturn(true)
+func Lt[T Ordered](value T) T {
+	return orderedMatcher(fmt.Sprintf("Lt(%v)", value), func(actual T) bool {
+		return actual < value
+	})
+}
+
+// Lte returns a matcher that matches values less than or equal to the provided value.
+// Example usage:
+//
+//	WhenSingle(myMock.SetAge(Lte(65))).ThenReturn(true)
+func Lte[T Ordered](value T) T {
+	return orderedMatcher(fmt.Sprintf("Lte(%v)", value), func(actual T) bool {
+		return actual <= value
+	})
+}
+
+// Between returns a matcher that matches values in the inclusive range from lo to hi.
+// Example usage:
+//
+//	WhenSingle(myMock.SetAge(Between(18, 65))).ThenReturn(true)
+//	WhenSingle(myMock.Lookup(Between("a", "m"))).ThenReturn(firstHalf)
+func Between[T Ordered](lo T, hi T) T {
+	return orderedMatcher(fmt.Sprintf("Between(%v, %v)", lo, hi), func(actual T) bool {
+		return actual >= lo && actual <= hi
+	})
+}
+
+// Approx returns a matcher that matches floats that differ from the provided value by no more than epsilon.
+// Example usage:
+//
+//	WhenSingle(myMock.Convert(Approx(0.3, 1e-9))).ThenReturn("0.3")
+func Approx[T ~float32 | ~float64](value T, epsilon T) T {
+	return orderedMatcher(fmt.Sprintf("Approx(%v, ±%v)", value, epsilon), func(actual T) bool {
+		return math.Abs(float64(actual)-float64(value)) <= float64(epsilon)
+	})
+}
+
+// After returns a matcher that matches times after the provided time.
+// Example usage:
+//
+//	WhenSingle(myMock.Schedule(After(time.Now()))).ThenReturn(nil)
+func After(t time.Time) time.Time {
+	return orderedMatcher(fmt.Sprintf("After(%v)", t.Round(0)), func(actual time.Time) bool {
+		return actual.After(t)
+	})
+}
+
+// Before returns a matcher that matches times before the provided time.
+// Example usage:
+//
+//	WhenSingle(myMock.Archive(Before(cutoff))).ThenReturn(nil)
+func Before(t time.Time) time.Time {
+	return orderedMatcher(fmt.Sprintf("Before(%v)", t.Round(0)), func(actual time.Time) bool {
+		return actual.Before(t)
+	})
+}
+
+// WithinDuration returns a matcher that matches times that differ from the provided time by no more than d.
+// Example usage:
+//
+//	WhenSingle(myMock.Save(WithinDuration(time.Now(), time.Second))).ThenReturn(nil)
+func WithinDuration(t time.Time, d time.Duration) time.Time {
+	return orderedMatcher(fmt.Sprintf("WithinDuration(%v, %v)", t.Round(0), d), func(actual time.Time) bool {
+		diff := actual.Sub(t)
+		return diff >= -d && diff <= d
+	})
+}
+
+// DurationBetween returns a matcher that matches durations in the inclusive range from lo to hi.
+// Example usage:
+//
+//	WhenSingle(myMock.SetTimeout(DurationBetween(time.Second, time.Minute))).ThenReturn(nil)
+func DurationBetween(lo time.Duration, hi time.Duration) time.Duration {
+	return orderedMatcher(fmt.Sprintf("DurationBetween(%v, %v)", lo, hi), func(actual time.Duration) bool {
+		return actual >= lo && actual <= hi
+	})
+}
+
+func orderedMatcher[T any](desc string, f func(actual T) bool) T {
+	m := registry.FunMatcher(desc, func(args []any, actual T) bool {
+		return f(actual)
+	})
+	registry.AddMatcher(m)
+	var t T
+	return t
+}
+
 // CreateMatcher returns a func that creates a custom matcher on invocation.
 func CreateMatcher[T any](description string, f func(allArgs []any, actual T) bool) func() T {
+	newMatcher := registry.MatcherFactory(description, f)
 	return func() T {
-		m := registry.FunMatcher[T](description, f)
-		registry.AddMatcher(m)
+		registry.AddMatcher(newMatcher())
 		var t T
 		return t
 	}
 }
 
+// F lists expected fields of a struct for the Fields matcher.
+// Keys are field names or dot-separated paths to nested fields.
+type F map[string]any
+
+// Fields returns a matcher that matches structs by the listed fields only, ignoring all other fields.
+// Values are compared with reflect.DeepEqual or the custom equality of the mock. Matchers can be used by wrapping them with Is.
+// Unknown fields, values of wrong types and numbers that the field type can not represent exactly
+// are reported when the stub is defined.
+// Example usage:
+//
+//	WhenSingle(myMock.Save(Fields[User](F{"Name": "bob", "Address.City": "Paris", "Age": Is(NotEqual(0))}))).ThenReturn(true)
+func Fields[T any](fields F) T {
+	var t T
+	m, err := registry.FieldsMatcher[T](fields)
+	if err != nil {
+		registry.AddInvalidMatcher(fmt.Sprintf("Fields[%s]", reflect.TypeOf(new(T)).Elem()), err)
+		return t
+	}
+	registry.AddMatcher(m)
+	return t
+}
+
+// Is converts the matcher declared by its argument to a value that can be used inside of F.
+// Map literals are evaluated in an unspecified order, so matchers inside F must be wrapped with Is.
+// Example usage:
+//
+//	Fields[User](F{"Name": Is(Regex("^b")), "Age": Is(OneOf(18, 21))})
+func Is[T any](value T) matchers.Matcher[any] {
+	ms, ok := registry.PopMatchers("Is", 1)
+	if !ok {
+		return registry.FunMatcher[any]("Invalid", func(args []any, actual any) bool {
+			return false
+		})
+	}
+	return ms[0]
+}
+
+// Not returns a matcher that matches values that do not match the given matcher.
+// The argument must be a matcher.
+// Example usage:
+//
+//	WhenSingle(myMock.MyMethod(Not(Substring("admin")))).ThenReturn("bar")
+func Not[T any](value T) T {
+	return combineMatchers[T]("Not", 1, func(ms []matchers.Matcher[any], args []any, actual any) bool {
+		return !ms[0].Match(args, actual)
+	})
+}
+
+// And returns a matcher that matches values that match both given matchers.
+// Both arguments must be matchers, use Exact or Equal for plain values.
+// Example usage:
+//
+//	WhenSingle(myMock.MyMethod(And(NotEqual(""), Not(Substring("admin"))))).ThenReturn("bar")
+func And[T any](a T, b T) T {
+	return combineMatchers[T]("And", 2, allMatch)
+}
+
+// Or returns a matcher that matches values that match at least one of given matchers.
+// Both arguments must be matchers, use Exact or Equal for plain values.
+// Example usage:
+//
+//	WhenSingle(myMock.MyMethod(Or(Regex("^foo"), Substring("bar")))).ThenReturn("baz")
+func Or[T any](a T, b T) T {
+	return combineMatchers[T]("Or", 2, anyMatch)
+}
+
+// AllOf returns a matcher that matches values that match all given matchers.
+// All arguments must be matchers, use Exact or Equal for plain values.
+// Example usage:
+//
+//	WhenSingle(myMock.MyMethod(AllOf(Regex("^a"), Substring("b"), Not(Substring("c"))))).ThenReturn("bar")
+func AllOf[T any](values ...T) T {
+	return combineMatchers[T]("AllOf", len(values), allMatch)
+}
+
+// AnyOf returns a matcher that matches values that match at least one of given matchers.
+// All arguments must be matchers, use Exact or Equal for plain values.
+// Example usage:
+//
+//	WhenSingle(myMock.MyMethod(AnyOf(Exact("a"), Regex("^b"), Substring("c")))).ThenReturn("bar")
+func AnyOf[T any](values ...T) T {
+	return combineMatchers[T]("AnyOf", len(values), anyMatch)
+}
+
+func combineMatchers[T any](name string, n int, f func(ms []matchers.Matcher[any], args []any, actual any) bool) T {
+	var t T
+	ms, ok := registry.PopMatchers(name, n)
+	if !ok {
+		return t
+	}
+	descs := make([]string, len(ms))
+	for i := range ms {
+		descs[i] = ms[i].Description()
+	}
+	desc := fmt.Sprintf("%s(%s)", name, strings.Join(descs, ", "))
+	m := registry.CombinedMatcher(desc, ms, f)
+	registry.AddMatcher(m)
+	return t
+}
+
+func allMatch(ms []matchers.Matcher[any], args []any, actual any) bool {
+	for _, m := range ms {
+		if !m.Match(args, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyMatch(ms []matchers.Matcher[any], args []any, actual any) bool {
+	for _, m := range ms {
+		if m.Match(args, actual) {
+			return true
+		}
+	}
+	return false
+}
+
 // WhenSingle takes an argument of type T and returns a ReturnerSingle interface
 // that allows for specifying a return value for a method call that has that argument.
 // This function should be used for method that returns exactly one return value
@@ -377,6 +702,27 @@ func WhenDouble[A any, B any](a A, b B) matchers.ReturnerDouble[A, B] {
 	return registry.ToReturnerDouble[A, B](registry.When())
 }
 
+// WhenTriple takes arguments of type A, B and C and returns a ReturnerTriple interface
+// that allows for specifying three return values for a method call that has that argument.
+// This function should be used for method that returns exactly three return values
+// It acts like When, but also provides additional type check on return values
+//
+// Example usage:
+//
+//	WhenTriple(cache.Get(AnyString())).ThenReturn(user, true, nil)
+func WhenTriple[A any, B any, C any](a A, b B, c C) matchers.ReturnerTriple[A, B, C] {
+	return registry.ToReturnerTriple[A, B, C](registry.When())
+}
+
+// WhenQuad takes arguments of type A, B, C and D and returns a ReturnerQuad interface
+// that allows for specifying four return values for a method call that has that argument.
+// This function should be used for method that returns exactly four return values
+// It acts like When, but also provides additional type check on return values
+// For more return values consider using When
+func WhenQuad[A any, B any, C any, D any](a A, b B, c C, d D) matchers.ReturnerQuad[A, B, C, D] {
+	return registry.ToReturnerQuad[A, B, C, D](registry.When())
+}
+
 // When sets up a method call expectation on a mocked object with a specified set of arguments
 // and returns a ReturnerAll object that allows specifying the return values or answer function
 // for the method call. Arguments can be any values, and the method call expectation is matched
@@ -436,6 +782,40 @@ func Verify[T any](t T, v matchers.MethodVerifier) T {
 	return t
 }
 
+// DoAnswer returns a Stubber that stubs a method of a mock of type T with the given answer.
+// Unlike When, it does not require a method call expression as an argument,
+// so it can be used for methods without return values.
+// The mock type has to be specified explicitly, since the stubbed method is called on the result of When.
+//
+// Example usage:
+//
+//	DoAnswer[Publisher](func(args []any) []any {
+//		published = append(published, args[0].(Msg))
+//		return nil
+//	}).When(publisher).Publish(Any[Msg]())
+func DoAnswer[T any](answer matchers.Answer) matchers.Stubber[T] {
+	return registry.DoAnswer[T](answer)
+}
+
+// DoPanic returns a Stubber that makes a method of a mock of type T panic with the given value.
+//
+// Example usage:
+//
+//	DoPanic[io.Closer]("closed twice").When(closer).Close()
+func DoPanic[T any](value any) matchers.Stubber[T] {
+	return registry.DoPanic[T](value)
+}
+
+// DoNothing returns a Stubber that makes a method of a mock of type T do nothing and return zero values.
+// It is useful with StrictVerify, where calls of methods that are not stubbed are reported.
+//
+// Example usage:
+//
+//	DoNothing[Cache]().When(cache).Set(AnyString(), Any[any]())
+func DoNothing[T any]() matchers.Stubber[T] {
+	return registry.DoNothing[T]()
+}
+
 // AtLeastOnce returns a MethodVerifier that verifies if the number of method calls
 // is greater than zero. It can be used to verify that a method has been called at least once.
 //
@@ -531,6 +911,72 @@ func VerifyNoMoreInteractions(value any) {
 	registry.VerifyNoMoreInteractions(value)
 }
 
+// Reset removes all stubbings and recorded calls of the mock object.
+// After reset, the mock behaves like a newly created one, and verification,
+// including the one on test teardown, only takes into account calls made after the reset.
+//
+// Example usage:
+//
+//	WhenSingle(mockObj.MyMethod("foo")).ThenReturn("bar")
+//	mockObj.MyMethod("foo")
+//
+//	Reset(mockObj)
+//
+//	// Returns zero value, since stubbing was removed
+//	mockObj.MyMethod("foo")
+//	Verify(mockObj, Once()).MyMethod("foo")
+func Reset(value any) {
+	registry.Reset(value)
+}
+
+// ClearInvocations removes recorded calls of the mock object, but keeps its stubbings.
+// It is useful to verify a test phase independently of previous ones.
+//
+// Example usage:
+//
+//	WhenSingle(mockObj.MyMethod("foo")).ThenReturn("bar")
+//	mockObj.MyMethod("foo")
+//
+//	ClearInvocations(mockObj)
+//
+//	// Still returns "bar"
+//	mockObj.MyMethod("foo")
+//	Verify(mockObj, Once()).MyMethod("foo")
+func ClearInvocations(value any) {
+	registry.ClearInvocations(value)
+}
+
+// InjectMocks creates a mock for every nil interface field of the struct pointed to by target.
+// Fields of type error and empty interfaces are skipped, like in deep stubs.
+// Only exported fields are populated, unless mockopts.InjectUnexported option is used.
+// Mock options, like mockopts.StrictVerify, are applied to every created mock.
+// Fields can be configured with the `mockio` struct tag:
+//   - `mockio:"-"` skips the field
+//   - `mockio:"name=repo"` names the mock, see mockopts.Name
+//
+// Injected mocks can be retrieved with Injected function, using the field name or the name from the tag.
+//
+// Example usage:
+//
+//	type Service struct {
+//		Users  UserRepo
+//		Orders OrderRepo `mockio:"name=orders"`
+//	}
+//
+//	svc := &Service{}
+//	mocks := InjectMocks(ctrl, svc)
+//	WhenSingle(Injected[UserRepo](mocks, "Users").Get(1)).ThenReturn(user)
+//	Verify(Injected[OrderRepo](mocks, "orders"), Once()).Save(AnyInt())
+func InjectMocks(ctrl *matchers.MockController, target any, opts ...config.InjectOption) matchers.InjectedMocks {
+	return registry.InjectMocks(ctrl, target, opts...)
+}
+
+// Injected returns the mock created by InjectMocks with the given name as type T.
+// It fails if there is no such mock, or if it does not implement T.
+func Injected[T any](mocks matchers.InjectedMocks, name string) T {
+	return registry.Injected[T](mocks, name)
+}
+
 func NewMockController(t matchers.ErrorReporter, opts ...config.Option) *matchers.MockController {
 	return registry.NewMockController(t, opts...)
 }
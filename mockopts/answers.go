@@ -0,0 +1,107 @@
+package mockopts
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ovechkin-dm/mockio/v2/config"
+)
+
+// ErrUnstubbedCall is returned for error outputs of unstubbed calls when ReturnErrors option is used.
+var ErrUnstubbedCall = config.ErrUnstubbedCall
+
+// WithDefaultAnswer sets a custom function that computes return values for calls that do not match any stubbing.
+// The function must return values that match the method signature.
+// Example:
+//
+//	ctrl := NewMockController(t, mockopts.WithDefaultAnswer(func(method reflect.Method, args []any) []any {
+//		return []any{"default", nil}
+//	}))
+func WithDefaultAnswer(answer func(method reflect.Method, args []any) []any) config.Option {
+	return func(cfg *config.MockConfig) {
+		cfg.DefaultAnswer = func(data *config.DefaultAnswerData) ([]any, error) {
+			return answer(data.Method, data.Args), nil
+		}
+	}
+}
+
+// ReturnZeroValues makes unstubbed calls return zero values.
+// This is the default behavior.
+func ReturnZeroValues() config.Option {
+	return func(cfg *config.MockConfig) {
+		cfg.DefaultAnswer = nil
+	}
+}
+
+// ReturnEmptyValues makes unstubbed calls return empty non-nil slices, maps and channels.
+// All other outputs are zero values.
+func ReturnEmptyValues() config.Option {
+	return func(cfg *config.MockConfig) {
+		cfg.DefaultAnswer = mapOutputs(emptyValue)
+	}
+}
+
+// ReturnErrors makes unstubbed calls return ErrUnstubbedCall for outputs of type error.
+// All other outputs are zero values.
+func ReturnErrors() config.Option {
+	return func(cfg *config.MockConfig) {
+		cfg.DefaultAnswer = mapOutputs(func(tp reflect.Type, data *config.DefaultAnswerData) reflect.Value {
+			if tp == reflect.TypeOf(&ErrUnstubbedCall).Elem() {
+				return reflect.ValueOf(&ErrUnstubbedCall).Elem()
+			}
+			return reflect.Zero(tp)
+		})
+	}
+}
+
+// ReturnSelf makes unstubbed calls return the mock itself for outputs of the mocked interface type.
+// It is useful for fluent builder interfaces. All other outputs are zero values.
+func ReturnSelf() config.Option {
+	return func(cfg *config.MockConfig) {
+		cfg.DefaultAnswer = mapOutputs(func(tp reflect.Type, data *config.DefaultAnswerData) reflect.Value {
+			if data.Mock != nil && tp.Kind() == reflect.Interface && tp.NumMethod() > 0 &&
+				reflect.TypeOf(data.Mock).AssignableTo(tp) {
+				return reflect.ValueOf(data.Mock)
+			}
+			return reflect.Zero(tp)
+		})
+	}
+}
+
+// FailOnUnstubbedCall makes every call that does not match any stubbing fail the test immediately.
+// The report contains the actual arguments of the call and all stubbings of the called method.
+// Calls with matchers are treated as stub definitions, so they never fail.
+// Stubbings with plain arguments, like When(mock.Foo(1)), are calls like any other and fail,
+// so stubbings must use matchers, or DoAnswer(...).When(mock) for methods without arguments.
+// Calls on spies are delegated to the real implementation and never fail.
+func FailOnUnstubbedCall() config.Option {
+	return func(cfg *config.MockConfig) {
+		cfg.DefaultAnswer = func(data *config.DefaultAnswerData) ([]any, error) {
+			return nil, fmt.Errorf("%w of method %s", ErrUnstubbedCall, data.Method.Name)
+		}
+	}
+}
+
+func mapOutputs(f func(tp reflect.Type, data *config.DefaultAnswerData) reflect.Value) config.DefaultAnswer {
+	return func(data *config.DefaultAnswerData) ([]any, error) {
+		tp := data.Method.Type
+		result := make([]any, tp.NumOut())
+		for i := range result {
+			result[i] = f(tp.Out(i), data).Interface()
+		}
+		return result, nil
+	}
+}
+
+func emptyValue(tp reflect.Type, data *config.DefaultAnswerData) reflect.Value {
+	switch tp.Kind() {
+	case reflect.Slice:
+		return reflect.MakeSlice(tp, 0, 0)
+	case reflect.Map:
+		return reflect.MakeMap(tp)
+	case reflect.Chan:
+		return reflect.MakeChan(reflect.ChanOf(reflect.BothDir, tp.Elem()), 0).Convert(tp)
+	default:
+		return reflect.Zero(tp)
+	}
+}
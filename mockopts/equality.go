@@ -0,0 +1,63 @@
+package mockopts
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/ovechkin-dm/mockio/v2/config"
+)
+
+// IgnoreFields skips struct fields with the given dot-separated paths when values are compared.
+// Fields of slice, array and map elements are addressed by the path of the container.
+// Example:
+//
+//	WhenSingle(repo.Save(EqualWith(user, mockopts.IgnoreFields("ID", "Address.UpdatedAt")))).ThenReturn(true)
+func IgnoreFields(paths ...string) config.EqualityOption {
+	return func(cfg *config.EqualityConfig) {
+		cfg.IgnoredFields = append(cfg.IgnoredFields, paths...)
+	}
+}
+
+// IgnoreUnexported skips unexported struct fields when values are compared.
+func IgnoreUnexported() config.EqualityOption {
+	return func(cfg *config.EqualityConfig) {
+		cfg.IgnoreUnexported = true
+	}
+}
+
+// NilEqualsEmpty treats nil slices and maps as equal to empty ones when values are compared.
+func NilEqualsEmpty() config.EqualityOption {
+	return func(cfg *config.EqualityConfig) {
+		cfg.NilEqualsEmpty = true
+	}
+}
+
+// FloatEpsilon treats floats as equal if they differ by no more than epsilon.
+func FloatEpsilon(epsilon float64) config.EqualityOption {
+	return func(cfg *config.EqualityConfig) {
+		cfg.FloatEpsilon = epsilon
+	}
+}
+
+// TimeTolerance treats times as equal if they differ by no more than the given duration.
+// Without this option times are compared with time.Time.Equal, which ignores monotonic clock readings and locations.
+func TimeTolerance(tolerance time.Duration) config.EqualityOption {
+	return func(cfg *config.EqualityConfig) {
+		cfg.TimeTolerance = tolerance
+	}
+}
+
+// Comparer sets a function that compares values of type T, including values nested in other values.
+// Example:
+//
+//	byID := mockopts.Comparer(func(a, b *User) bool { return a.ID == b.ID })
+//	WhenSingle(repo.Save(EqualWith(users, byID))).ThenReturn(true)
+func Comparer[T any](f func(a, b T) bool) config.EqualityOption {
+	return func(cfg *config.EqualityConfig) {
+		cfg.Comparers[reflect.TypeOf(new(T)).Elem()] = func(a, b any) bool {
+			ta, _ := a.(T)
+			tb, _ := b.(T)
+			return f(ta, tb)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package mockopts
 
 import (
+	"reflect"
+
 	"github.com/ovechkin-dm/mockio/v2/config"
 )
 
@@ -16,6 +18,17 @@ func WithoutStackTrace() config.Option {
 	}
 }
 
+// Name sets the name that identifies a mock in error reports.
+// It is useful when a test has several mocks of the same type.
+// It can only be passed to Mock, Spy and MockFunc, passing it to NewMockController fails the test.
+// Example:
+//
+//	primary := Mock[Store](ctrl, mockopts.Name("primary"))
+//	replica := Mock[Store](ctrl, mockopts.Name("replica"))
+func Name(name string) config.Option {
+	return config.WithName(name)
+}
+
 // StrictVerify enables strict verification of mock calls.
 // This means that all mocked methods that are not called will be reported as errors,
 // and all not mocked methods that are called will be reported as errors.
@@ -25,3 +38,94 @@ func StrictVerify() config.Option {
 		cfg.StrictVerify = true
 	}
 }
+
+// WithoutStrictVerify disables strict verification of mock calls.
+// It is useful to exclude a single mock from strict verification enabled on controller:
+//
+//	ctrl := NewMockController(t, mockopts.StrictVerify())
+//	logger := Mock[Logger](ctrl, mockopts.WithoutStrictVerify())
+func WithoutStrictVerify() config.Option {
+	return func(cfg *config.MockConfig) {
+		cfg.StrictVerify = false
+	}
+}
+
+// DeepStubs enables automatic mocking of interface-typed return values.
+// An unstubbed method that returns an interface will return a mock of that interface
+// instead of nil. The same mock is returned on every call of the method,
+// so chained calls can be stubbed and verified:
+//
+//	ctrl := NewMockController(t, mockopts.DeepStubs())
+//	client := Mock[Client](ctrl)
+//	WhenDouble(client.Users().Get("id")).ThenReturn(user, nil)
+//
+// By default, deep stubs are disabled.
+func DeepStubs() config.Option {
+	return func(cfg *config.MockConfig) {
+		cfg.DeepStubs = true
+	}
+}
+
+// InjectUnexported makes InjectMocks populate unexported fields as well.
+// By default, only exported fields are populated.
+// Example:
+//
+//	mocks := InjectMocks(ctrl, &svc, mockopts.InjectUnexported())
+func InjectUnexported() config.InjectOption {
+	return config.InjectConfigOption(func(cfg *config.InjectConfig) {
+		cfg.Unexported = true
+	})
+}
+
+// WhenExhausted sets what stubbings answer once all of their consecutive answers were used.
+// By default, the last answer is repeated.
+// The policy can be overridden for a single stubbing, see matchers.ReturnerAll.
+// Example:
+//
+//	ctrl := NewMockController(t, mockopts.WhenExhausted(config.FailWhenExhausted))
+func WhenExhausted(policy config.ExhaustionPolicy) config.Option {
+	return func(cfg *config.MockConfig) {
+		cfg.ExhaustionPolicy = policy
+	}
+}
+
+// LastStubbingWins makes the most recent matching stubbing answer a call.
+// A new stubbing with the same matchers as an existing one replaces it,
+// so a stubbing from a shared setup can be redefined in a test:
+//
+//	ctrl := NewMockController(t, mockopts.LastStubbingWins())
+//	WhenSingle(repo.Get("a")).ThenReturn("setup")
+//	WhenSingle(repo.Get("a")).ThenReturn("test") // repo.Get("a") returns "test"
+//
+// By default, calls are answered by the first matching stubbing.
+func LastStubbingWins() config.Option {
+	return func(cfg *config.MockConfig) {
+		cfg.LastStubbingWins = true
+	}
+}
+
+// WithEquality sets a function that compares values of type T.
+// It is used instead of reflect.DeepEqual by matchers that compare values for equality:
+// implicit matchers of plain arguments, Equal, NotEqual, OneOf and SliceContains.
+// The function is also used for values of type T nested in other values.
+// Example:
+//
+//	ctrl := NewMockController(t, mockopts.WithEquality(func(a, b decimal.Decimal) bool {
+//		return a.Equal(b)
+//	}))
+//	WhenSingle(ledger.Credit(decimal.RequireFromString("1.50"))).ThenReturn(true)
+//	ledger.Credit(decimal.RequireFromString("1.5")) // returns true
+func WithEquality[T any](eq func(a, b T) bool) config.Option {
+	return func(cfg *config.MockConfig) {
+		equality := make(map[reflect.Type]func(a, b any) bool, len(cfg.Equality)+1)
+		for tp, f := range cfg.Equality {
+			equality[tp] = f
+		}
+		cfg.Equality = equality
+		cfg.Equality[reflect.TypeOf(new(T)).Elem()] = func(a, b any) bool {
+			ta, _ := a.(T)
+			tb, _ := b.(T)
+			return eq(ta, tb)
+		}
+	}
+}
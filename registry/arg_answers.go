@@ -0,0 +1,133 @@
+package registry
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// argEffect writes values through arguments of a call.
+// Arguments are flattened, so variadic arguments are addressed by their position in the call.
+type argEffect func(args []reflect.Value) error
+
+var bytesType = reflect.TypeOf([]byte(nil))
+
+// argType returns the declared type of the flattened argument of a stubbed method.
+// numArgs is the number of arguments of the stubbing, which is fixed by its matchers.
+func argType(method reflect.Method, numArgs int, index int) (reflect.Type, error) {
+	if index < 0 || index >= numArgs {
+		return nil, fmt.Errorf("argument index %d is out of range, the stubbing has %d arguments", index, numArgs)
+	}
+	tp := method.Type
+	if tp.IsVariadic() && index >= tp.NumIn()-1 {
+		return tp.In(tp.NumIn() - 1).Elem(), nil
+	}
+	return tp.In(index), nil
+}
+
+func newSetArgEffect(method reflect.Method, numArgs int, index int, value any) (argEffect, error) {
+	tp, err := argType(method, numArgs, index)
+	if err != nil {
+		return nil, err
+	}
+	switch tp.Kind() {
+	case reflect.Pointer:
+		if err := checkAssignable(index, tp.Elem(), value); err != nil {
+			return nil, err
+		}
+	case reflect.Interface:
+	default:
+		return nil, fmt.Errorf("argument %d of type %s is not a pointer", index, tp.String())
+	}
+	return func(args []reflect.Value) error {
+		arg := unwrapInterface(args[index])
+		if arg.Kind() != reflect.Pointer || arg.IsNil() {
+			return fmt.Errorf("argument %d is not a non-nil pointer, got %v", index, describeValue(arg))
+		}
+		if err := checkAssignable(index, arg.Type().Elem(), value); err != nil {
+			return err
+		}
+		if value == nil {
+			arg.Elem().Set(reflect.Zero(arg.Type().Elem()))
+		} else {
+			arg.Elem().Set(reflect.ValueOf(value))
+		}
+		return nil
+	}, nil
+}
+
+func newCopyToArgEffect(method reflect.Method, numArgs int, index int, data []byte) (argEffect, error) {
+	tp, err := argType(method, numArgs, index)
+	if err != nil {
+		return nil, err
+	}
+	if tp.Kind() != reflect.Interface && !isBytes(tp) {
+		return nil, fmt.Errorf("argument %d of type %s is not a byte slice", index, tp.String())
+	}
+	return func(args []reflect.Value) error {
+		arg := unwrapInterface(args[index])
+		if !arg.IsValid() || !isBytes(arg.Type()) {
+			return fmt.Errorf("argument %d is not a byte slice, got %v", index, describeValue(arg))
+		}
+		reflect.Copy(arg, reflect.ValueOf(data))
+		return nil
+	}, nil
+}
+
+func newSetVariadicArgsEffect(method reflect.Method, numArgs int, values []any) (argEffect, error) {
+	tp := method.Type
+	if !tp.IsVariadic() {
+		return nil, fmt.Errorf("method %s is not variadic", method.Name)
+	}
+	numFixed := tp.NumIn() - 1
+	if len(values) > numArgs-numFixed {
+		return nil, fmt.Errorf("%d values are set, but the stubbing has %d variadic arguments", len(values), numArgs-numFixed)
+	}
+	effects := make([]argEffect, len(values))
+	for i := range values {
+		effect, err := newSetArgEffect(method, numArgs, numFixed+i, values[i])
+		if err != nil {
+			return nil, err
+		}
+		effects[i] = effect
+	}
+	return func(args []reflect.Value) error {
+		return applyArgEffects(effects, args)
+	}, nil
+}
+
+func applyArgEffects(effects []argEffect, args []reflect.Value) error {
+	for _, effect := range effects {
+		if err := effect(args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkAssignable(index int, tp reflect.Type, value any) error {
+	if value == nil {
+		return nil
+	}
+	if !reflect.TypeOf(value).AssignableTo(tp) {
+		return fmt.Errorf("value of type %s can not be assigned to argument %d of type *%s", reflect.TypeOf(value).String(), index, tp.String())
+	}
+	return nil
+}
+
+func isBytes(tp reflect.Type) bool {
+	return tp.Kind() == reflect.Slice && tp.Elem() == bytesType.Elem()
+}
+
+func unwrapInterface(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v
+}
+
+func describeValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return v.Type().String()
+}
@@ -0,0 +1,272 @@
+package registry
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/ovechkin-dm/mockio/v2/config"
+	"github.com/ovechkin-dm/mockio/v2/matchers"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type equalWithMatcher[T any] struct {
+	value T
+	cfg   *config.EqualityConfig
+	desc  string
+}
+
+// EqualWithMatcher creates a matcher that compares values like reflect.DeepEqual,
+// except for the differences that are ignored by the config.
+// Ignored field paths are checked against the type T when the matcher is created.
+func EqualWithMatcher[T any](value T, cfg *config.EqualityConfig) (matchers.Matcher[T], error) {
+	tp := reflect.TypeOf(new(T)).Elem()
+	for _, path := range cfg.IgnoredFields {
+		if err := checkFieldPath(tp, path); err != nil {
+			return nil, err
+		}
+	}
+	return &equalWithMatcher[T]{
+		value: value,
+		cfg:   cfg,
+		desc:  fmt.Sprintf("EqualWith(%v)", value),
+	}, nil
+}
+
+func (m *equalWithMatcher[T]) Description() string {
+	return m.desc
+}
+
+// identity makes EqualWith matchers with equal descriptions different, since the description does not include the options.
+func (m *equalWithMatcher[T]) identity() any {
+	return m
+}
+
+func (m *equalWithMatcher[T]) Match(allArgs []any, actual T) bool {
+	return m.DescribeMismatch(allArgs, actual) == ""
+}
+
+func (m *equalWithMatcher[T]) DescribeMismatch(allArgs []any, actual any) string {
+	ok, path, reason := DeepEqualWith(m.cfg, m.value, actual)
+	if ok {
+		return ""
+	}
+	if path == "" {
+		return reason
+	}
+	return fmt.Sprintf("%s: %s", path, reason)
+}
+
+// DeepEqualWith compares values like reflect.DeepEqual, except for the differences that are ignored by the config.
+// If values are not equal, it returns the path and the description of the first difference.
+func DeepEqualWith(cfg *config.EqualityConfig, expected any, actual any) (bool, string, string) {
+	e := &equality{
+		cfg:     cfg,
+		ignored: make(map[string]struct{}),
+		visited: make(map[visit]struct{}),
+	}
+	for _, path := range cfg.IgnoredFields {
+		e.ignored[path] = struct{}{}
+	}
+	return e.equal(reflect.ValueOf(expected), reflect.ValueOf(actual), "", "")
+}
+
+type visit struct {
+	a  unsafe.Pointer
+	b  unsafe.Pointer
+	tp reflect.Type
+}
+
+type equality struct {
+	cfg     *config.EqualityConfig
+	ignored map[string]struct{}
+	visited map[visit]struct{}
+}
+
+// equal compares two values.
+// fieldPath addresses struct fields only and is used to match ignored fields, path also includes indexes and keys.
+func (e *equality) equal(a reflect.Value, b reflect.Value, fieldPath string, path string) (bool, string, string) {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() == b.IsValid() {
+			return true, "", ""
+		}
+		return false, path, fmt.Sprintf("expected %v, got %v", describeNil(a), describeNil(b))
+	}
+	if a.Type() != b.Type() {
+		return false, path, fmt.Sprintf("expected type %s, got %s", a.Type().String(), b.Type().String())
+	}
+	a = accessible(a)
+	b = accessible(b)
+	if cmp, ok := e.cfg.Comparers[a.Type()]; ok {
+		if cmp(a.Interface(), b.Interface()) {
+			return true, "", ""
+		}
+		return false, path, fmt.Sprintf("expected %v, got %v", a, b)
+	}
+	if a.Type() == timeType {
+		return e.equalTime(a.Interface().(time.Time), b.Interface().(time.Time), path)
+	}
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() == b.IsNil() {
+				return true, "", ""
+			}
+			return false, path, fmt.Sprintf("expected %v, got %v", a, b)
+		}
+		v := visit{a: a.UnsafePointer(), b: b.UnsafePointer(), tp: a.Type()}
+		if _, ok := e.visited[v]; ok || v.a == v.b {
+			return true, "", ""
+		}
+		e.visited[v] = struct{}{}
+		return e.equal(a.Elem(), b.Elem(), fieldPath, path)
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() == b.IsNil() {
+				return true, "", ""
+			}
+			return false, path, fmt.Sprintf("expected %v, got %v", a, b)
+		}
+		return e.equal(a.Elem(), b.Elem(), fieldPath, path)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if e.cfg.IgnoreUnexported && !field.IsExported() {
+				continue
+			}
+			fp := joinPath(fieldPath, field.Name)
+			if _, ok := e.ignored[fp]; ok {
+				continue
+			}
+			if ok, p, reason := e.equal(a.Field(i), b.Field(i), fp, joinPath(path, field.Name)); !ok {
+				return false, p, reason
+			}
+		}
+		return true, "", ""
+	case reflect.Slice, reflect.Map:
+		if a.Len() != b.Len() {
+			return false, path, fmt.Sprintf("expected length %d, got %d", a.Len(), b.Len())
+		}
+		if a.IsNil() != b.IsNil() && !e.cfg.NilEqualsEmpty {
+			return false, path, fmt.Sprintf("expected %s, got %s", describeNilOrEmpty(a), describeNilOrEmpty(b))
+		}
+		if a.Kind() == reflect.Map {
+			return e.equalMap(a, b, fieldPath, path)
+		}
+		if a.UnsafePointer() == b.UnsafePointer() {
+			return true, "", ""
+		}
+		return e.equalElements(a, b, fieldPath, path)
+	case reflect.Array:
+		return e.equalElements(a, b, fieldPath, path)
+	case reflect.Float32, reflect.Float64:
+		if a.Float() == b.Float() || math.Abs(a.Float()-b.Float()) <= e.cfg.FloatEpsilon {
+			return true, "", ""
+		}
+		return false, path, fmt.Sprintf("expected %v, got %v", a, b)
+	case reflect.Func:
+		if a.IsNil() && b.IsNil() {
+			return true, "", ""
+		}
+		return false, path, "functions are equal only if both are nil"
+	default:
+		if a.Equal(b) {
+			return true, "", ""
+		}
+		return false, path, fmt.Sprintf("expected %v, got %v", a, b)
+	}
+}
+
+func (e *equality) equalTime(a time.Time, b time.Time, path string) (bool, string, string) {
+	diff := a.Sub(b)
+	if diff < 0 {
+		diff = -diff
+	}
+	if a.Equal(b) || diff <= e.cfg.TimeTolerance {
+		return true, "", ""
+	}
+	return false, path, fmt.Sprintf("expected %v, got %v", a, b)
+}
+
+func (e *equality) equalElements(a reflect.Value, b reflect.Value, fieldPath string, path string) (bool, string, string) {
+	for i := 0; i < a.Len(); i++ {
+		if ok, p, reason := e.equal(a.Index(i), b.Index(i), fieldPath, fmt.Sprintf("%s[%d]", path, i)); !ok {
+			return false, p, reason
+		}
+	}
+	return true, "", ""
+}
+
+func (e *equality) equalMap(a reflect.Value, b reflect.Value, fieldPath string, path string) (bool, string, string) {
+	iter := a.MapRange()
+	for iter.Next() {
+		elemPath := fmt.Sprintf("%s[%v]", path, iter.Key())
+		bv := b.MapIndex(iter.Key())
+		if !bv.IsValid() {
+			return false, elemPath, "key is missing"
+		}
+		if ok, p, reason := e.equal(iter.Value(), bv, fieldPath, elemPath); !ok {
+			return false, p, reason
+		}
+	}
+	return true, "", ""
+}
+
+// accessible returns a value that can be converted to an interface and whose fields can be read.
+// Values of unexported fields are addressable, since containers are made addressable before their fields are read.
+func accessible(v reflect.Value) reflect.Value {
+	if !v.CanInterface() {
+		return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+	}
+	if !v.CanAddr() {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		return c
+	}
+	return v
+}
+
+// checkFieldPath checks that the dot-separated path addresses a struct field of the type.
+// Pointers, slices, arrays and maps along the path are traversed to their elements.
+func checkFieldPath(tp reflect.Type, path string) error {
+	cur := tp
+	for _, name := range strings.Split(path, ".") {
+		for cur.Kind() == reflect.Pointer || cur.Kind() == reflect.Slice || cur.Kind() == reflect.Array || cur.Kind() == reflect.Map {
+			cur = cur.Elem()
+		}
+		if cur.Kind() != reflect.Struct {
+			return fmt.Errorf("ignored field %s: type %s is not a struct", path, cur.String())
+		}
+		field, ok := cur.FieldByName(name)
+		if !ok {
+			return fmt.Errorf("ignored field %s: type %s has no field %s", path, cur.String(), name)
+		}
+		cur = field.Type
+	}
+	return nil
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func describeNil(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func describeNilOrEmpty(v reflect.Value) string {
+	if v.IsNil() {
+		return "nil"
+	}
+	return "empty"
+}
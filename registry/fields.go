@@ -0,0 +1,266 @@
+package registry
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ovechkin-dm/mockio/v2/matchers"
+)
+
+// fieldCheck matches a single field of a struct, addressed by a dot-separated path.
+type fieldCheck struct {
+	path    string
+	steps   []fieldStep
+	matcher matchers.Matcher[any]
+}
+
+// fieldStep is an index of a struct field on the path to the checked field.
+// Fields promoted from embedded structs take several steps.
+type fieldStep struct {
+	index int
+	owner string
+}
+
+type fieldsMatcher[T any] struct {
+	checks []*fieldCheck
+	desc   string
+}
+
+// FieldsMatcher creates a matcher that matches structs by the listed fields only.
+// Keys are field names or dot-separated paths to nested fields, like "Address.City".
+// Values are either plain values compared with reflect.DeepEqual or the custom equality of the mock, or matchers.
+// Fields are checked against the struct type T when the matcher is created.
+func FieldsMatcher[T any](fields map[string]any) (matchers.Matcher[T], error) {
+	tp := reflect.TypeOf(new(T)).Elem()
+	paths := make([]string, 0, len(fields))
+	for path := range fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	checks := make([]*fieldCheck, 0, len(paths))
+	descs := make([]string, 0, len(paths))
+	for _, path := range paths {
+		check, err := newFieldCheck(tp, path, fields[path])
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+		descs = append(descs, fmt.Sprintf("%s: %s", path, check.matcher.Description()))
+	}
+	return &fieldsMatcher[T]{
+		checks: checks,
+		desc:   fmt.Sprintf("Fields[%s](%s)", tp.String(), strings.Join(descs, ", ")),
+	}, nil
+}
+
+func newFieldCheck(tp reflect.Type, path string, value any) (*fieldCheck, error) {
+	names := strings.Split(path, ".")
+	steps := make([]fieldStep, 0, len(names))
+	owner := ""
+	cur := tp
+	for i, name := range names {
+		for cur.Kind() == reflect.Pointer {
+			cur = cur.Elem()
+		}
+		if cur.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("field %s: %s is not a struct", path, describePath(tp, names[:i]))
+		}
+		field, ok := cur.FieldByName(name)
+		if !ok {
+			return nil, fmt.Errorf("field %s: type %s has no field %s", path, cur.String(), name)
+		}
+		for j, idx := range field.Index {
+			steps = append(steps, fieldStep{index: idx, owner: owner})
+			owner = joinPath(owner, cur.FieldByIndex(field.Index[:j+1]).Name)
+		}
+		cur = field.Type
+	}
+	matcher, err := fieldValueMatcher(cur, value)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", path, err)
+	}
+	return &fieldCheck{
+		path:    path,
+		steps:   steps,
+		matcher: matcher,
+	}, nil
+}
+
+// fieldValueMatcher returns the matcher of a field value, or an equality matcher for a plain value.
+// Numeric values are converted to the field type, so that untyped constants can be used for fields of any numeric type.
+// Values that the field type can not represent exactly are rejected.
+func fieldValueMatcher(tp reflect.Type, value any) (matchers.Matcher[any], error) {
+	if m, ok := value.(matchers.Matcher[any]); ok {
+		return m, nil
+	}
+	if value != nil {
+		vt := reflect.TypeOf(value)
+		switch {
+		case vt.AssignableTo(tp):
+		case isNumber(vt) && isNumber(tp):
+			converted, err := convertNumber(reflect.ValueOf(value), tp)
+			if err != nil {
+				return nil, err
+			}
+			value = converted.Interface()
+		default:
+			return nil, fmt.Errorf("value of type %s can not be compared with field of type %s", vt.String(), tp.String())
+		}
+	} else if !isNillable(tp) {
+		return nil, fmt.Errorf("nil can not be compared with field of type %s", tp.String())
+	}
+	expected := valueOrZero(value, tp).Interface()
+	return EqualityMatcher(fmt.Sprintf("%v", value), func(eq Equality, args []any, actual any) bool {
+		return eq(expected, actual)
+	}), nil
+}
+
+func (m *fieldsMatcher[T]) Description() string {
+	return m.desc
+}
+
+func (m *fieldsMatcher[T]) Match(allArgs []any, actual T) bool {
+	return m.DescribeMismatch(allArgs, actual) == ""
+}
+
+func (m *fieldsMatcher[T]) DescribeMismatch(allArgs []any, actual any) string {
+	root := reflect.New(reflect.TypeOf(new(T)).Elem()).Elem()
+	if actual != nil {
+		v := reflect.ValueOf(actual)
+		if !v.Type().AssignableTo(root.Type()) {
+			return fmt.Sprintf("value of type %s is not %s", v.Type().String(), root.Type().String())
+		}
+		root.Set(v)
+	}
+	for _, check := range m.checks {
+		if reason := check.mismatch(allArgs, root); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+func (m *fieldsMatcher[T]) bindEquality(eq Equality) matchers.Matcher[any] {
+	checks := make([]*fieldCheck, len(m.checks))
+	for i, check := range m.checks {
+		checks[i] = &fieldCheck{
+			path:    check.path,
+			steps:   check.steps,
+			matcher: bindMatcher(check.matcher, eq),
+		}
+	}
+	return untypedMatcher[T](&fieldsMatcher[T]{
+		checks: checks,
+		desc:   m.desc,
+	})
+}
+
+// mismatch walks the field path step by step, so that nil pointers, including embedded ones, are reported instead of panicking.
+func (c *fieldCheck) mismatch(allArgs []any, root reflect.Value) string {
+	cur := root
+	for _, step := range c.steps {
+		for cur.Kind() == reflect.Pointer {
+			if cur.IsNil() {
+				return fmt.Sprintf("field %s: %s is nil", c.path, describeOwner(root.Type(), step.owner))
+			}
+			cur = cur.Elem()
+		}
+		cur = cur.Field(step.index)
+	}
+	actual := accessible(cur).Interface()
+	if c.matcher.Match(allArgs, actual) {
+		return ""
+	}
+	if d, ok := c.matcher.(matchers.MismatchDescriber); ok {
+		return fmt.Sprintf("field %s: %s", c.path, d.DescribeMismatch(allArgs, actual))
+	}
+	return fmt.Sprintf("field %s: expected %s, got %v", c.path, c.matcher.Description(), actual)
+}
+
+func describePath(tp reflect.Type, names []string) string {
+	if len(names) == 0 {
+		return tp.String()
+	}
+	return strings.Join(names, ".")
+}
+
+func describeOwner(tp reflect.Type, owner string) string {
+	if owner == "" {
+		return tp.String()
+	}
+	return owner
+}
+
+// convertNumber converts a numeric value to the numeric type.
+// It fails for fractional values of integer types, negative values of unsigned types and values that overflow the type.
+func convertNumber(v reflect.Value, tp reflect.Type) (reflect.Value, error) {
+	target := reflect.New(tp).Elem()
+	var exact bool
+	switch {
+	case isInt(tp):
+		switch {
+		case isInt(v.Type()):
+			exact = !target.OverflowInt(v.Int())
+		case isUint(v.Type()):
+			exact = v.Uint() <= math.MaxInt64 && !target.OverflowInt(int64(v.Uint()))
+		default:
+			f := v.Float()
+			exact = f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !target.OverflowInt(int64(f))
+		}
+	case isUint(tp):
+		switch {
+		case isInt(v.Type()):
+			exact = v.Int() >= 0 && !target.OverflowUint(uint64(v.Int()))
+		case isUint(v.Type()):
+			exact = !target.OverflowUint(v.Uint())
+		default:
+			f := v.Float()
+			exact = f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !target.OverflowUint(uint64(f))
+		}
+	default:
+		switch {
+		case isInt(v.Type()):
+			exact = !target.OverflowFloat(float64(v.Int()))
+		case isUint(v.Type()):
+			exact = !target.OverflowFloat(float64(v.Uint()))
+		default:
+			exact = !target.OverflowFloat(v.Float())
+		}
+	}
+	if !exact {
+		return reflect.Value{}, fmt.Errorf("value %v of type %s can not be represented by field of type %s", v, v.Type().String(), tp.String())
+	}
+	return v.Convert(tp), nil
+}
+
+func isInt(tp reflect.Type) bool {
+	switch tp.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUint(tp reflect.Type) bool {
+	switch tp.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNumber(tp reflect.Type) bool {
+	switch tp.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -1,11 +1,14 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"sync"
 
+	"github.com/ovechkin-dm/mockio/v2/config"
 	"github.com/ovechkin-dm/mockio/v2/matchers"
+	"github.com/ovechkin-dm/mockio/v2/threadlocal"
 	"github.com/ovechkin-dm/mockio/v2/utils"
 )
 
@@ -16,6 +19,10 @@ type invocationHandler struct {
 	lock         sync.Mutex
 	env          *matchers.MockEnv
 	reporter     *EnrichedReporter
+	delegate     reflect.Value
+	factory      matchers.MockFactory
+	self         reflect.Value
+	done         chan struct{}
 }
 
 func (h *invocationHandler) Handle(method reflect.Method, values []reflect.Value) []reflect.Value {
@@ -28,6 +35,13 @@ func (h *invocationHandler) Handle(method reflect.Method, values []reflect.Value
 	if h.ctx.getState().verifyState {
 		return h.DoVerifyMethod(call)
 	}
+	if h.ctx.getState().stubState {
+		h.markDeepStubChain(call)
+		return h.DoStubMethod(call)
+	}
+	if chain := h.ctx.getState().deepStubChain; chain != nil && chain.handler != matchers.Handler(h) {
+		h.ctx.getState().deepStubChain = nil
+	}
 	h.methods[method.Name].calls.Add(call)
 	return h.DoAnswer(call)
 }
@@ -36,9 +50,15 @@ func (h *invocationHandler) DoAnswer(c *MethodCall) []reflect.Value {
 	rec := h.methods[c.Method.Name]
 	h.ctx.getState().whenHandler = h
 	h.ctx.getState().whenCall = c
+	h.ctx.getState().whenMethodMatch = nil
+	h.ctx.getState().whenAnswer = nil
 	var matched bool
 	methodMatches := rec.methodMatches.GetCopy()
-	for _, mm := range methodMatches {
+	for i := range methodMatches {
+		mm := methodMatches[i]
+		if h.env.Config.LastStubbingWins {
+			mm = methodMatches[len(methodMatches)-1-i]
+		}
 		matched = true
 		if len(mm.matchers) != len(c.Values) {
 			continue
@@ -50,6 +70,11 @@ func (h *invocationHandler) DoAnswer(c *MethodCall) []reflect.Value {
 			}
 		}
 		if matched {
+			stubCallIndex, ok := mm.tryUse()
+			if !ok {
+				continue
+			}
+			h.ctx.getState().whenMethodMatch = mm
 			ifaces := valueSliceToInterfaceSlice(c.Values)
 
 			for i, m := range mm.matchers {
@@ -58,15 +83,47 @@ func (h *invocationHandler) DoAnswer(c *MethodCall) []reflect.Value {
 				}
 			}
 
-			ansWrapper := mm.popAnswer()
-			if ansWrapper == nil {
+			ansWrapper, exhausted := mm.popAnswer()
+			if exhausted && !h.hasPendingMatchers() {
+				h.reporter.ReportStubExhausted(h.instanceType, c, mm)
 				return createDefaultReturnValues(c.Method)
 			}
-
-			retValues := ansWrapper.ans(ifaces)
+			if ansWrapper == nil {
+				return h.defaultReturnValues(c)
+			}
 
 			h.ctx.getState().whenAnswer = ansWrapper
-			h.ctx.getState().whenMethodMatch = mm
+
+			if len(ansWrapper.effects) != 0 && !h.hasPendingMatchers() {
+				if err := applyArgEffects(ansWrapper.effects, c.Values); err != nil {
+					h.reporter.ReportArgAnswerError(h.instanceType, c, err)
+					return createDefaultReturnValues(c.Method)
+				}
+			}
+
+			if !ansWrapper.hasAnswer() {
+				return h.defaultReturnValues(c)
+			}
+
+			if ansWrapper.callRealMethod || ansWrapper.panics || ansWrapper.do.IsValid() {
+				if h.hasPendingMatchers() {
+					return createDefaultReturnValues(c.Method)
+				}
+				if ansWrapper.panics {
+					panic(ansWrapper.panicValue)
+				}
+				if ansWrapper.do.IsValid() {
+					return callFunc(ansWrapper.do, c.Method.Type, c.Values)
+				}
+				return h.callDelegate(c)
+			}
+
+			var retValues []any
+			if ansWrapper.invocationAns != nil {
+				retValues = ansWrapper.invocationAns(h.newInvocation(c, mm, stubCallIndex))
+			} else {
+				retValues = ansWrapper.ans(ifaces)
+			}
 
 			if !h.validateReturnValues(retValues, c.Method) {
 				h.reporter.ReportInvalidReturnValues(h.instanceType, c.Method, retValues)
@@ -77,7 +134,158 @@ func (h *invocationHandler) DoAnswer(c *MethodCall) []reflect.Value {
 			return result
 		}
 	}
-	return createDefaultReturnValues(c.Method)
+	if h.delegate.IsValid() && !h.hasPendingMatchers() {
+		return h.callDelegate(c)
+	}
+	return h.defaultReturnValues(c)
+}
+
+func (h *invocationHandler) newInvocation(c *MethodCall, mm *methodMatch, stubCallIndex int) matchers.Invocation {
+	methodCallIndex := 0
+	for _, other := range h.methods[c.Method.Name].calls.GetCopy() {
+		if other == c {
+			break
+		}
+		if !other.WhenCall {
+			methodCallIndex++
+		}
+	}
+	inv := matchers.Invocation{
+		MethodName:      c.Method.Name,
+		MethodType:      c.Method,
+		Args:            valueSliceToInterfaceSlice(c.Values),
+		StubCallIndex:   stubCallIndex,
+		MethodCallIndex: methodCallIndex,
+		GoroutineID:     threadlocal.GoId(),
+		StubbedAt:       mm.stackTrace.CallerLine(),
+	}
+	if h.self.IsValid() {
+		inv.Mock = h.self.Interface()
+	}
+	return inv
+}
+
+// failOnUnstubbedCall reports a call that does not match any stubbing.
+func (h *invocationHandler) failOnUnstubbedCall(c *MethodCall, methodMatches []*methodMatch) {
+	h.reporter.ReportUnstubbedCall(h.instanceType, c, methodMatches)
+}
+
+// defaultReturnValues returns values for a call that has no stubbed answer.
+// Calls with pending matchers are stub definitions, so they are not passed to the configured default answer.
+func (h *invocationHandler) defaultReturnValues(c *MethodCall) []reflect.Value {
+	result := h.callDefaultAnswer(c)
+	if !h.env.Config.DeepStubs {
+		return result
+	}
+	for i := range result {
+		if !result[i].IsZero() {
+			continue
+		}
+		child, ok := h.deepStub(c.Method, i)
+		if ok {
+			result[i] = child.value
+			h.ctx.getState().deepStubChain = &deepStubLink{
+				handler: child.handler,
+				call:    c,
+				prev:    h.ctx.getState().deepStubChain,
+			}
+		}
+	}
+	return result
+}
+
+func (h *invocationHandler) callDefaultAnswer(c *MethodCall) []reflect.Value {
+	answer := h.env.Config.DefaultAnswer
+	if answer == nil || h.hasPendingMatchers() {
+		return createDefaultReturnValues(c.Method)
+	}
+	data := &config.DefaultAnswerData{
+		Method: c.Method,
+		Args:   valueSliceToInterfaceSlice(c.Values),
+	}
+	if h.self.IsValid() {
+		data.Mock = h.self.Interface()
+	}
+	retValues, err := answer(data)
+	if errors.Is(err, config.ErrUnstubbedCall) {
+		h.failOnUnstubbedCall(c, h.methods[c.Method.Name].methodMatches.GetCopy())
+		return createDefaultReturnValues(c.Method)
+	}
+	if err != nil {
+		h.reporter.ReportDefaultAnswerError(h.instanceType, c, err)
+		return createDefaultReturnValues(c.Method)
+	}
+	if !h.validateReturnValues(retValues, c.Method) {
+		h.reporter.ReportInvalidReturnValues(h.instanceType, c.Method, retValues)
+		return createDefaultReturnValues(c.Method)
+	}
+	return interfaceSliceToValueSlice(retValues, c.Method)
+}
+
+// deepStub returns a child mock for the interface-typed output of a method.
+// The child is created once, so chained calls on it can be stubbed and verified.
+func (h *invocationHandler) deepStub(method reflect.Method, outIdx int) (*deepStub, bool) {
+	tp := method.Type.Out(outIdx)
+	if !isDeepStubbable(tp) || h.factory == nil {
+		return nil, false
+	}
+	rec := h.methods[method.Name]
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+	if child, ok := rec.deepStubs[outIdx]; ok {
+		return child, true
+	}
+	env := h.env
+	if h.env.Config.Name != "" {
+		cfg := *h.env.Config
+		cfg.Name = fmt.Sprintf("%s.%s()", h.env.Config.Name, method.Name)
+		env = &matchers.MockEnv{
+			Reporter: h.env.Reporter,
+			Config:   &cfg,
+		}
+	}
+	handler := h.factory.BuildHandler(env, tp)
+	value, err := newMockValue(handler, tp)
+	if err != nil {
+		return nil, false
+	}
+	if rec.deepStubs == nil {
+		rec.deepStubs = make(map[int]*deepStub)
+	}
+	child := &deepStub{value: value, handler: handler}
+	rec.deepStubs[outIdx] = child
+	return child, true
+}
+
+// markDeepStubChain marks the calls that returned this mock as a deep stub as stub definitions,
+// so that stubbing a chain like client.Users().Get("id") does not leave an unverified call of Users().
+// The chain is dropped by any call on another mock, so only calls made right before the stubbing are marked.
+// The stubbed call itself can also return a deep stub, then it is the last link of the chain.
+func (h *invocationHandler) markDeepStubChain(call *MethodCall) {
+	chain := h.ctx.getState().deepStubChain
+	h.ctx.getState().deepStubChain = nil
+	if chain == nil || chain.handler != matchers.Handler(h) && chain.call != call {
+		return
+	}
+	for link := chain; link != nil; link = link.prev {
+		link.call.WhenCall = true
+	}
+}
+
+// hasPendingMatchers reports whether matchers were declared for the current call.
+// Such a call is a stub definition, and its arguments are placeholders that must not reach the real implementation.
+func (h *invocationHandler) hasPendingMatchers() bool {
+	return len(h.ctx.getState().matchers) != 0
+}
+
+// callDelegate invokes the real implementation wrapped by a spy.
+func (h *invocationHandler) callDelegate(c *MethodCall) []reflect.Value {
+	method := h.delegate.MethodByName(c.Method.Name)
+	if !method.IsValid() {
+		h.reporter.ReportDelegateMethodNotFound(h.instanceType, c.Method)
+		return createDefaultReturnValues(c.Method)
+	}
+	return callFunc(method, c.Method.Type, c.Values)
 }
 
 func (h *invocationHandler) When() matchers.ReturnerAll {
@@ -92,6 +300,7 @@ func (h *invocationHandler) When() matchers.ReturnerAll {
 		return nil
 	}
 	whenCall.WhenCall = true
+	h.markDeepStubChain(whenCall)
 
 	if whenMethodMatch != nil {
 		for _, m := range whenMethodMatch.matchers {
@@ -106,27 +315,101 @@ func (h *invocationHandler) When() matchers.ReturnerAll {
 	h.ctx.getState().whenMethodMatch = nil
 	h.ctx.getState().whenAnswer = nil
 
-	if whenAnswer != nil && whenMethodMatch != nil {
-		whenMethodMatch.putBackAnswer(whenAnswer)
+	if whenMethodMatch != nil {
+		whenMethodMatch.releaseUse()
+		if whenAnswer != nil {
+			whenMethodMatch.putBackAnswer(whenAnswer)
+		}
 	}
 
 	if !h.validateMatchers(whenCall) {
 		return NewEmptyReturner()
 	}
 
-	rec := h.methods[whenCall.Method.Name]
+	m := h.addStubbing(whenCall)
+	return NewReturnerAll(h, m)
+}
+
+// addStubbing creates a stubbing of the called method with the declared matchers.
+func (h *invocationHandler) addStubbing(call *MethodCall) *methodMatch {
+	rec := h.methods[call.Method.Name]
 
 	argMatchers := h.ctx.getState().matchers
 
 	h.ctx.getState().matchers = make([]*matcherWrapper, 0)
 	m := &methodMatch{
+		method:     call.Method,
 		matchers:   argMatchers,
 		unanswered: make([]*answerWrapper, 0),
 		answered:   make([]*answerWrapper, 0),
 		stackTrace: NewStackTrace(),
+		exhaustion: h.env.Config.ExhaustionPolicy,
 	}
 	rec.methodMatches.Add(m)
-	return NewReturnerAll(h.ctx, m)
+	if h.env.Config.LastStubbingWins {
+		h.overrideStubbing(m)
+	}
+	return m
+}
+
+// StubMethod makes the next call on the mock a stub definition with the given answer.
+// A nil answer makes the stubbed method do nothing and return zero values.
+func (h *invocationHandler) StubMethod(answer *answerWrapper) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.ctx.getState().stubState = true
+	h.ctx.getState().stubAnswer = answer
+	if len(h.ctx.getState().matchers) != 0 {
+		h.reporter.ReportUnexpectedMatcherDeclaration(h.ctx.getState().matchers)
+	}
+}
+
+func (h *invocationHandler) DoStubMethod(call *MethodCall) []reflect.Value {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	answer := h.ctx.getState().stubAnswer
+	h.ctx.getState().stubState = false
+	h.ctx.getState().stubAnswer = nil
+	if !h.validateMatchers(call) {
+		h.ctx.getState().matchers = make([]*matcherWrapper, 0)
+		return createDefaultReturnValues(call.Method)
+	}
+	if answer == nil {
+		answer = &answerWrapper{
+			ans: makeReturnFunc(valueSliceToInterfaceSlice(createDefaultReturnValues(call.Method))),
+		}
+	}
+	m := h.addStubbing(call)
+	m.addAnswer(answer)
+	return createDefaultReturnValues(call.Method)
+}
+
+// overrideStubbing replaces stubbings of the method that have the same matchers as the given one.
+// The given stubbing takes the place of the first replaced one,
+// unless the most recent stubbing wins, in which case it stays the last one.
+func (h *invocationHandler) overrideStubbing(m *methodMatch) {
+	lastWins := h.env.Config.LastStubbingWins
+	h.methods[m.method.Name].methodMatches.Update(func(items []*methodMatch) []*methodMatch {
+		result := make([]*methodMatch, 0, len(items))
+		placed := false
+		for _, other := range items {
+			switch {
+			case other == m:
+			case sameMatchers(other.matchers, m.matchers):
+				if lastWins {
+					continue
+				}
+			default:
+				result = append(result, other)
+				continue
+			}
+			if !placed {
+				result = append(result, m)
+				placed = true
+			}
+		}
+		return result
+	})
 }
 
 func (h *invocationHandler) VerifyMethod(verifier matchers.MethodVerifier) {
@@ -191,6 +474,7 @@ func (h *invocationHandler) DoVerifyMethod(call *MethodCall) []reflect.Value {
 			call.Method,
 			matchedInvocations,
 			argMatchers,
+			nil,
 			h.methods[call.Method.Name],
 			err,
 			nil,
@@ -207,31 +491,32 @@ func (h *invocationHandler) DoVerifyMethod(call *MethodCall) []reflect.Value {
 }
 
 // newHandler creates a new invocationHandler.
-// The `tp` parameter represents the reflector type for the target interface.
+// The `tp` parameter represents the reflector type for the target interface or func.
 func newHandler(tp reflect.Type, holder *mockContext, env *matchers.MockEnv) *invocationHandler {
 	recorders := make(map[string]*methodRecorder)
-	for i := 0; i < tp.NumMethod(); i++ {
-		recorders[tp.Method(i).Name] = &methodRecorder{
+	for _, method := range methodsOf(tp) {
+		recorders[method.Name] = &methodRecorder{
 			methodMatches: utils.NewSyncList[*methodMatch](),
 			calls:         utils.NewSyncList[*MethodCall](),
-			methodType:    tp.Method(i),
+			methodType:    method,
 		}
 	}
 	return newInvocationHandler(holder, recorders, tp, env)
 }
 
 func (h *invocationHandler) validateMatchers(call *MethodCall) bool {
+	if report := h.ctx.getState().invalidMatchers; report != nil {
+		h.ctx.getState().invalidMatchers = nil
+		h.ctx.getState().matchers = make([]*matcherWrapper, 0)
+		report(h.reporter)
+		return false
+	}
 	argMatchers := h.ctx.getState().matchers
 	if len(argMatchers) == 0 {
 		ifaces := valueSliceToInterfaceSlice(call.Values)
 		for _, v := range ifaces {
-			cur := v
-			desc := fmt.Sprintf("Equal(%v)", v)
-			fm := FunMatcher(desc, func(call []any, a any) bool {
-				return reflect.DeepEqual(cur, a)
-			})
 			mw := &matcherWrapper{
-				matcher: fm,
+				matcher: EqualMatcher(v),
 				rec:     nil,
 			}
 			argMatchers = append(argMatchers, mw)
@@ -242,9 +527,29 @@ func (h *invocationHandler) validateMatchers(call *MethodCall) bool {
 		h.reporter.ReportInvalidUseOfMatchers(h.instanceType, call, argMatchers)
 		return false
 	}
+	if len(h.env.Config.Equality) != 0 {
+		eq := h.equality()
+		for _, w := range argMatchers {
+			w.matcher = bindMatcher(w.matcher, eq)
+		}
+	}
 	return true
 }
 
+// equality returns the equality of values for matchers of the mock.
+// Custom equalities from the config are used for values of their types, including nested values.
+func (h *invocationHandler) equality() Equality {
+	if len(h.env.Config.Equality) == 0 {
+		return reflect.DeepEqual
+	}
+	cfg := config.NewEqualityConfig()
+	cfg.Comparers = h.env.Config.Equality
+	return func(a, b any) bool {
+		ok, _, _ := DeepEqualWith(cfg, a, b)
+		return ok
+	}
+}
+
 func (h *invocationHandler) validateReturnValues(result []any, method reflect.Method) bool {
 	if method.Type.NumOut() != len(result) {
 		return false
@@ -342,6 +647,7 @@ func (h *invocationHandler) PostponedVerify(tearDown bool) {
 						rec.methodType,
 						matchedInvocations,
 						match.matchers,
+						match,
 						rec,
 						err,
 						stackTrace,
@@ -352,7 +658,37 @@ func (h *invocationHandler) PostponedVerify(tearDown bool) {
 	}
 }
 
+// Reset removes all stubbings and recorded calls of the mock.
+// Calls that are in flight are either recorded after the reset, or not recorded at all.
+func (h *invocationHandler) Reset() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.ctx.getState().whenHandler == h {
+		h.ctx.getState().whenHandler = nil
+		h.ctx.getState().whenCall = nil
+		h.ctx.getState().whenAnswer = nil
+		h.ctx.getState().whenMethodMatch = nil
+	}
+	for _, rec := range h.methods {
+		rec.methodMatches.Clear()
+		rec.calls.Clear()
+		rec.lock.Lock()
+		rec.deepStubs = nil
+		rec.lock.Unlock()
+	}
+}
+
+// ClearInvocations removes recorded calls of the mock, but keeps its stubbings.
+func (h *invocationHandler) ClearInvocations() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, rec := range h.methods {
+		rec.calls.Clear()
+	}
+}
+
 func (h *invocationHandler) TearDown() {
+	close(h.done)
 	if h.env.Config.StrictVerify {
 		for _, m := range h.methods {
 			methodMatches := m.methodMatches.GetCopy()
@@ -381,6 +717,7 @@ func newInvocationHandler(
 		lock:         sync.Mutex{},
 		env:          env,
 		reporter:     newEnrichedReporter(env.Reporter, env.Config),
+		done:         make(chan struct{}),
 	}
 	return handler
 }
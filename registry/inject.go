@@ -0,0 +1,125 @@
+package registry
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+
+	"github.com/ovechkin-dm/mockio/v2/config"
+	"github.com/ovechkin-dm/mockio/v2/matchers"
+)
+
+const InjectTagName = "mockio"
+
+type injectedMocksImpl struct {
+	mocks map[string]any
+	names []string
+}
+
+func (i *injectedMocksImpl) Get(name string) (any, bool) {
+	m, ok := i.mocks[name]
+	return m, ok
+}
+
+func (i *injectedMocksImpl) Names() []string {
+	result := make([]string, len(i.names))
+	copy(result, i.names)
+	return result
+}
+
+func (i *injectedMocksImpl) add(name string, mock any) {
+	i.mocks[name] = mock
+	i.names = append(i.names, name)
+}
+
+func InjectMocks(ctrl *matchers.MockController, target any, opts ...config.InjectOption) matchers.InjectedMocks {
+	result := &injectedMocksImpl{
+		mocks: make(map[string]any),
+		names: make([]string, 0),
+	}
+	tv := reflect.ValueOf(target)
+	if !tv.IsValid() || tv.Kind() != reflect.Pointer || tv.IsNil() || tv.Elem().Kind() != reflect.Struct {
+		getInstance().reporter.FailNow(fmt.Errorf("error injecting mocks: expected a non-nil pointer to struct, got %T", target))
+		return result
+	}
+	cfg := config.NewInjectConfig()
+	for _, opt := range opts {
+		opt.ApplyInject(cfg)
+	}
+	sv := tv.Elem()
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		if !isDeepStubbable(field.Type) {
+			continue
+		}
+		if !field.IsExported() && !cfg.Unexported {
+			continue
+		}
+		name, skip, err := parseInjectTag(field)
+		if err != nil {
+			getInstance().reporter.FailNow(fmt.Errorf("error injecting mocks into %s: %w", st.String(), err))
+			return result
+		}
+		fv := sv.Field(i)
+		if skip || !fv.IsNil() {
+			continue
+		}
+		if _, ok := result.mocks[name]; ok {
+			getInstance().reporter.FailNow(fmt.Errorf("error injecting mocks into %s: duplicate mock name %q", st.String(), name))
+			return result
+		}
+		mockOpts := cfg.MockOptions
+		if name != field.Name {
+			mockOpts = append(append([]config.Option{}, cfg.MockOptions...), config.WithName(name))
+		}
+		mock, err := newMockValue(ctrl.MockFactory.BuildHandler(mockEnv(ctrl, mockOpts), field.Type), field.Type)
+		if err != nil {
+			getInstance().reporter.FailNow(fmt.Errorf("error injecting mock into field %s.%s: %w", st.String(), field.Name, err))
+			return result
+		}
+		if !field.IsExported() {
+			fv = reflect.NewAt(field.Type, unsafe.Pointer(fv.UnsafeAddr())).Elem()
+		}
+		fv.Set(mock)
+		result.add(name, mock.Interface())
+	}
+	return result
+}
+
+func Injected[T any](mocks matchers.InjectedMocks, name string) T {
+	var zero T
+	m, ok := mocks.Get(name)
+	if !ok {
+		getInstance().reporter.FailNow(fmt.Errorf("no mock was injected with name %q, injected mocks are: %s", name, strings.Join(mocks.Names(), ", ")))
+		return zero
+	}
+	result, ok := m.(T)
+	if !ok {
+		tp := reflect.TypeOf(new(T)).Elem()
+		getInstance().reporter.FailNow(fmt.Errorf("mock injected with name %q does not implement %s", name, tp.String()))
+		return zero
+	}
+	return result
+}
+
+// parseInjectTag returns the name of the mock for the field, and whether the field must be skipped.
+func parseInjectTag(field reflect.StructField) (string, bool, error) {
+	tag, ok := field.Tag.Lookup(InjectTagName)
+	if !ok {
+		return field.Name, false, nil
+	}
+	if tag == "-" {
+		return field.Name, true, nil
+	}
+	name := field.Name
+	for _, opt := range strings.Split(tag, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(opt), "=")
+		if !found || key != "name" || value == "" {
+			return "", false, fmt.Errorf("invalid tag `%s:\"%s\"` on field %s", InjectTagName, tag, field.Name)
+		}
+		name = value
+	}
+	return name, false, nil
+}
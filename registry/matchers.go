@@ -23,9 +23,29 @@ func FunMatcher[T any](description string, f func([]any, T) bool) matchers.Match
 	}
 }
 
+// MatcherFactory returns a func that creates matchers with the given description and function.
+// Matchers of one factory are the same when stubbings are compared, matchers of different factories are not,
+// even if they have the same description.
+func MatcherFactory[T any](description string, f func([]any, T) bool) func() matchers.Matcher[T] {
+	id := new(int)
+	return func() matchers.Matcher[T] {
+		return &matcherImpl[T]{
+			f:    f,
+			desc: description,
+			id:   id,
+		}
+	}
+}
+
 type matcherImpl[T any] struct {
 	f    func([]any, T) bool
 	desc string
+	id   any
+	src  any
+}
+
+func (m *matcherImpl[T]) identity() any {
+	return m.id
 }
 
 func (m *matcherImpl[T]) Description() string {
@@ -36,7 +56,168 @@ func (m *matcherImpl[T]) Match(allArgs []any, actual T) bool {
 	return m.f(allArgs, actual)
 }
 
+// describedMatcher is an untyped matcher that keeps the mismatch description of the source matcher.
+type describedMatcher struct {
+	matchers.Matcher[any]
+	matchers.MismatchDescriber
+}
+
+// Equality compares two values for equality.
+type Equality = func(a, b any) bool
+
+// equalityBinder is implemented by matchers that compare values for equality.
+// Mocks bind such matchers to the custom equality of their config when the matchers are consumed.
+type equalityBinder interface {
+	bindEquality(eq Equality) matchers.Matcher[any]
+}
+
+// bindableMatcher is an untyped matcher that keeps the equality binding of the source matcher.
+type bindableMatcher struct {
+	matchers.Matcher[any]
+	binder equalityBinder
+}
+
+func (m *bindableMatcher) bindEquality(eq Equality) matchers.Matcher[any] {
+	return m.binder.bindEquality(eq)
+}
+
+// describedBindableMatcher is an untyped matcher that keeps both the mismatch description and the equality binding of the source matcher.
+type describedBindableMatcher struct {
+	describedMatcher
+	binder equalityBinder
+}
+
+func (m *describedBindableMatcher) bindEquality(eq Equality) matchers.Matcher[any] {
+	return m.binder.bindEquality(eq)
+}
+
+type equalityMatcherImpl[T any] struct {
+	desc     string
+	eq       Equality
+	f        func(eq Equality, args []any, actual T) bool
+	expected any
+	valued   bool
+}
+
+// EqualityMatcher creates a matcher that compares values with the given equality.
+// The equality is reflect.DeepEqual, unless the mock that consumes the matcher is configured with a custom one.
+func EqualityMatcher[T any](description string, f func(eq Equality, args []any, actual T) bool) matchers.Matcher[T] {
+	return &equalityMatcherImpl[T]{
+		desc: description,
+		eq:   reflect.DeepEqual,
+		f:    f,
+	}
+}
+
+// EqualMatcher creates a matcher that matches values equal to the given value.
+// It is used by Equal and for plain arguments, and keeps the value, so that stubbings
+// with values that are printed the same, like 1 and "1", are not the same.
+func EqualMatcher[T any](value T) matchers.Matcher[T] {
+	return &equalityMatcherImpl[T]{
+		desc: fmt.Sprintf("Equal(%v)", value),
+		eq:   reflect.DeepEqual,
+		f: func(eq Equality, args []any, actual T) bool {
+			return eq(value, actual)
+		},
+		expected: value,
+		valued:   true,
+	}
+}
+
+func (m *equalityMatcherImpl[T]) expectedValue() (any, bool) {
+	return m.expected, m.valued
+}
+
+func (m *equalityMatcherImpl[T]) Description() string {
+	return m.desc
+}
+
+func (m *equalityMatcherImpl[T]) Match(allArgs []any, actual T) bool {
+	return m.f(m.eq, allArgs, actual)
+}
+
+func (m *equalityMatcherImpl[T]) bindEquality(eq Equality) matchers.Matcher[any] {
+	return untypedMatcher[T](&equalityMatcherImpl[T]{
+		desc:     m.desc,
+		eq:       eq,
+		f:        m.f,
+		expected: m.expected,
+		valued:   m.valued,
+	})
+}
+
+type combinedMatcher struct {
+	desc     string
+	children []matchers.Matcher[any]
+	f        func(ms []matchers.Matcher[any], args []any, actual any) bool
+}
+
+// CombinedMatcher creates a matcher that matches by the results of other matchers.
+// Equality of the other matchers is bound together with the combined matcher.
+func CombinedMatcher(description string, children []matchers.Matcher[any], f func(ms []matchers.Matcher[any], args []any, actual any) bool) matchers.Matcher[any] {
+	return &combinedMatcher{
+		desc:     description,
+		children: children,
+		f:        f,
+	}
+}
+
+func (m *combinedMatcher) Description() string {
+	return m.desc
+}
+
+func (m *combinedMatcher) Match(allArgs []any, actual any) bool {
+	return m.f(m.children, allArgs, actual)
+}
+
+func (m *combinedMatcher) bindEquality(eq Equality) matchers.Matcher[any] {
+	children := make([]matchers.Matcher[any], len(m.children))
+	for i := range m.children {
+		children[i] = bindMatcher(m.children[i], eq)
+	}
+	return untypedMatcher[any](&combinedMatcher{
+		desc:     m.desc,
+		children: children,
+		f:        m.f,
+	})
+}
+
+// bindMatcher binds the matcher to the equality, if the matcher compares values for equality.
+func bindMatcher(m matchers.Matcher[any], eq Equality) matchers.Matcher[any] {
+	if b, ok := m.(equalityBinder); ok {
+		return b.bindEquality(eq)
+	}
+	return m
+}
+
 func untypedMatcher[T any](src matchers.Matcher[T]) matchers.Matcher[any] {
+	result := untypedMatcherImpl(src)
+	d, describes := src.(matchers.MismatchDescriber)
+	b, binds := src.(equalityBinder)
+	switch {
+	case describes && binds:
+		return &describedBindableMatcher{
+			describedMatcher: describedMatcher{
+				Matcher:           result,
+				MismatchDescriber: d,
+			},
+			binder: b,
+		}
+	case describes:
+		return &describedMatcher{
+			Matcher:           result,
+			MismatchDescriber: d,
+		}
+	case binds:
+		return &bindableMatcher{
+			Matcher: result,
+			binder:  b,
+		}
+	}
+	return result
+}
+
+func untypedMatcherImpl[T any](src matchers.Matcher[T]) matchers.Matcher[any] {
 	return &matcherImpl[any]{
 		f: func(args []any, a any) bool {
 			var casted T
@@ -51,5 +232,34 @@ func untypedMatcher[T any](src matchers.Matcher[T]) matchers.Matcher[any] {
 			return src.Match(args, casted)
 		},
 		desc: src.Description(),
+		src:  src,
+	}
+}
+
+// identifiedMatcher is implemented by matchers whose behavior is not fully defined by their description.
+// Such matchers are the same only if their identities are equal.
+type identifiedMatcher interface {
+	identity() any
+}
+
+// valuedMatcher is implemented by matchers that compare actual values with an expected value.
+// Such matchers are the same only if their expected values are of the same type and deeply equal.
+type valuedMatcher interface {
+	expectedValue() (any, bool)
+}
+
+// matcherSource returns the typed matcher that the untyped matcher was created from.
+func matcherSource(m matchers.Matcher[any]) any {
+	switch w := m.(type) {
+	case *describedMatcher:
+		m = w.Matcher
+	case *bindableMatcher:
+		m = w.Matcher
+	case *describedBindableMatcher:
+		m = w.Matcher
+	}
+	if impl, ok := m.(*matcherImpl[any]); ok && impl.src != nil {
+		return impl.src
 	}
+	return m
 }
@@ -19,6 +19,9 @@ type HandlerHolder interface {
 
 var instance = threadlocal.NewThreadLocal(newRegistry)
 
+// funcHandlers maps func mocks to their handlers, since func values can not carry a payload.
+var funcHandlers sync.Map
+
 type Registry struct {
 	mockContext *mockContext
 	reporter    *EnrichedReporter
@@ -34,10 +37,62 @@ func getInstance() *Registry {
 	return v
 }
 
-func Mock[T any](ctrl *matchers.MockController) T {
+func Mock[T any](ctrl *matchers.MockController, opts ...config.Option) T {
 	tp := reflect.TypeOf(new(T)).Elem()
-	handler := ctrl.MockFactory.BuildHandler(ctrl.Env, tp)
-	t, err := dyno.DynamicByType(handler.Handle, tp, dynoopts.WithPayload(handler))
+	handler := ctrl.MockFactory.BuildHandler(mockEnv(ctrl, opts), tp)
+	return createMock[T](handler, tp)
+}
+
+func Spy[T any](ctrl *matchers.MockController, delegate T, opts ...config.Option) T {
+	tp := reflect.TypeOf(new(T)).Elem()
+	if tp.Kind() != reflect.Interface {
+		getInstance().reporter.FailNow(fmt.Errorf("error creating spy: %s is not an interface", tp.String()))
+		var zero T
+		return zero
+	}
+	dv := reflect.ValueOf(&delegate).Elem()
+	if dv.IsNil() {
+		getInstance().reporter.FailNow(fmt.Errorf("error creating spy: delegate of type %s is nil", tp.String()))
+		var zero T
+		return zero
+	}
+	handler := ctrl.MockFactory.BuildHandler(mockEnv(ctrl, opts), tp)
+	ih, ok := handler.(*invocationHandler)
+	if !ok {
+		getInstance().reporter.FailNow(fmt.Errorf("error creating spy: unsupported handler type %T", handler))
+		var zero T
+		return zero
+	}
+	ih.delegate = dv
+	return createMock[T](handler, tp)
+}
+
+func MockFunc[F any](ctrl *matchers.MockController, opts ...config.Option) F {
+	tp := reflect.TypeOf(new(F)).Elem()
+	if tp.Kind() != reflect.Func {
+		getInstance().reporter.FailNow(fmt.Errorf("error creating mock: %s is not a func type", tp.String()))
+		var zero F
+		return zero
+	}
+	handler := ctrl.MockFactory.BuildHandler(mockEnv(ctrl, opts), tp)
+	method := funcMethod(tp)
+	fn := reflect.MakeFunc(tp, func(args []reflect.Value) []reflect.Value {
+		return handler.Handle(method, args)
+	})
+	result := fn.Interface().(F)
+	if ih, ok := handler.(*invocationHandler); ok {
+		ih.self = fn
+	}
+	key := funcValuePointer(result)
+	funcHandlers.Store(key, handler)
+	ctrl.Env.Reporter.Cleanup(func() {
+		funcHandlers.Delete(key)
+	})
+	return result
+}
+
+func createMock[T any](handler matchers.Handler, tp reflect.Type) T {
+	t, err := newDynamic(handler, tp)
 	if err != nil {
 		getInstance().reporter.FailNow(fmt.Errorf("error creating mock: %w", err))
 		var zero T
@@ -49,9 +104,46 @@ func Mock[T any](ctrl *matchers.MockController) T {
 		var zero T
 		return zero
 	}
+	if ih, ok := handler.(*invocationHandler); ok {
+		ih.self = reflect.ValueOf(&result).Elem()
+	}
 	return result
 }
 
+// mockEnv returns environment for a single mock.
+// Per-mock options are applied to a copy of the controller config.
+func mockEnv(ctrl *matchers.MockController, opts []config.Option) *matchers.MockEnv {
+	if len(opts) == 0 {
+		return ctrl.Env
+	}
+	cfg := *ctrl.Env.Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &matchers.MockEnv{
+		Reporter: ctrl.Env.Reporter,
+		Config:   &cfg,
+	}
+}
+
+func newDynamic(handler matchers.Handler, tp reflect.Type) (any, error) {
+	return dyno.DynamicByType(handler.Handle, tp, dynoopts.WithPayload(handler))
+}
+
+// newMockValue creates a mock of the interface type and returns it as a value of that type.
+func newMockValue(handler matchers.Handler, tp reflect.Type) (reflect.Value, error) {
+	obj, err := newDynamic(handler, tp)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	result := reflect.New(tp).Elem()
+	result.Set(reflect.ValueOf(obj))
+	if ih, ok := handler.(*invocationHandler); ok {
+		ih.self = result
+	}
+	return result, nil
+}
+
 func AddMatcher[T any](m matchers.Matcher[T]) {
 	w := &matcherWrapper{
 		matcher:    untypedMatcher(m),
@@ -90,6 +182,35 @@ func VerifyMethod(t any, v matchers.MethodVerifier) {
 	handler.VerifyMethod(v)
 }
 
+func StubMethod(t any, answer *answerWrapper) {
+	handler := UnwrapHandler(t)
+	if handler == nil {
+		return
+	}
+	handler.StubMethod(answer)
+}
+
+func DoAnswer[T any](answer matchers.Answer) matchers.Stubber[T] {
+	return &stubberImpl[T]{
+		answer: &answerWrapper{
+			ans: answer,
+		},
+	}
+}
+
+func DoPanic[T any](value any) matchers.Stubber[T] {
+	return &stubberImpl[T]{
+		answer: &answerWrapper{
+			panics:     true,
+			panicValue: value,
+		},
+	}
+}
+
+func DoNothing[T any]() matchers.Stubber[T] {
+	return &stubberImpl[T]{}
+}
+
 func VerifyNoMoreInteractions(t any) {
 	handler := UnwrapHandler(t)
 	if handler == nil {
@@ -98,6 +219,22 @@ func VerifyNoMoreInteractions(t any) {
 	handler.VerifyNoMoreInteractions(false)
 }
 
+func Reset(t any) {
+	handler := UnwrapHandler(t)
+	if handler == nil {
+		return
+	}
+	handler.Reset()
+}
+
+func ClearInvocations(t any) {
+	handler := UnwrapHandler(t)
+	if handler == nil {
+		return
+	}
+	handler.ClearInvocations()
+}
+
 func newRegistry() *Registry {
 	cfg := &config.MockConfig{
 		PrintStackTrace: false,
@@ -126,6 +263,10 @@ func NewMockController(reporter matchers.ErrorReporter, opts ...config.Option) *
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	if cfg.Name != "" {
+		newEnrichedReporter(reporter, cfg).ReportControllerMockName()
+		cfg.Name = ""
+	}
 	env := &matchers.MockEnv{
 		Reporter: reporter,
 		Config:   cfg,
@@ -144,6 +285,17 @@ func UnwrapHandler(mock any) *invocationHandler {
 	}
 	handlerHolder, ok := mock.(HandlerHolder)
 	var handler *invocationHandler
+	if mock != nil && reflect.TypeOf(mock).Kind() == reflect.Func {
+		h, found := funcHandlers.Load(funcValuePointer(mock))
+		if found {
+			handler, ok = h.(*invocationHandler)
+			if ok {
+				return handler
+			}
+		}
+		getInstance().reporter.ReportUnregisteredMockVerify(mock)
+		return nil
+	}
 	if ok {
 		handler, handlerOk := handlerHolder.Handler().(*invocationHandler)
 		if handlerOk {
@@ -167,6 +319,46 @@ type mockFactoryImpl struct{}
 
 func (m *mockFactoryImpl) BuildHandler(env *matchers.MockEnv, ifaceType reflect.Type) matchers.Handler {
 	handler := newHandler(ifaceType, getInstance().mockContext, env)
+	handler.factory = m
 	env.Reporter.Cleanup(handler.TearDown)
 	return handler
 }
+
+// PopMatchers removes the last n matchers declared in the current goroutine and returns them in declaration order.
+// It is used by matcher combinators to replace the matchers of their arguments with a single composite matcher.
+// Misuse is reported by the mock that consumes the matchers, since the mock is not known when matchers are declared.
+func PopMatchers(combinator string, n int) ([]matchers.Matcher[any], bool) {
+	state := getInstance().mockContext.getState()
+	start := len(state.matchers) - n
+	if start < 0 {
+		start = 0
+	}
+	popped := state.matchers[start:]
+	state.matchers = state.matchers[:len(state.matchers)-len(popped)]
+	valid := len(popped) == n
+	result := make([]matchers.Matcher[any], len(popped))
+	for i, w := range popped {
+		valid = valid && w.rec == nil
+		result[i] = w.matcher
+	}
+	if !valid {
+		setInvalidMatchers(func(reporter *EnrichedReporter) {
+			reporter.ReportInvalidMatcherComposition(combinator, n, popped)
+		})
+		return nil, false
+	}
+	return result, true
+}
+
+// AddInvalidMatcher records a matcher that could not be created.
+// The error is reported by the mock that consumes the matchers.
+func AddInvalidMatcher(description string, err error) {
+	stackTrace := NewStackTrace()
+	setInvalidMatchers(func(reporter *EnrichedReporter) {
+		reporter.ReportInvalidMatcher(description, stackTrace, err)
+	})
+}
+
+func setInvalidMatchers(report func(reporter *EnrichedReporter)) {
+	getInstance().mockContext.getState().invalidMatchers = report
+}
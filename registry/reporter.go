@@ -75,6 +75,11 @@ func (e *EnrichedReporter) Fatalf(format string, args ...any) {
 	e.reporter.Fatalf(format, args...)
 }
 
+func (e *EnrichedReporter) ReportControllerMockName() {
+	e.StackTraceFatalf(`mockopts.Name can not be passed to NewMockController, since it would give every mock the same name.
+	Pass it to Mock, Spy or MockFunc instead, for example: Mock[Store](ctrl, mockopts.Name("replica"))`)
+}
+
 func (e *EnrichedReporter) ReportIncorrectWhenUsage() {
 	e.StackTraceFatalf(`When() requires an argument which has to be 'a method call on a mock'.
 	For example: When(mock.GetArticles()).ThenReturn(articles)`)
@@ -95,7 +100,7 @@ func (e *EnrichedReporter) ReportInvalidUseOfMatchers(instanceType reflect.Type,
 	matchersString := strings.Join(matcherArgs, ",")
 	tp := call.Method.Type
 	inArgs := make([]string, 0)
-	methodSig := prettyPrintMethodSignature(instanceType, call.Method)
+	methodSig := prettyPrintMethodSignature(instanceType, e.cfg.Name, call.Method)
 	for i := 0; i < tp.NumIn(); i++ {
 		inArgs = append(inArgs, tp.In(i).String())
 	}
@@ -131,6 +136,7 @@ func (e *EnrichedReporter) ReportVerifyMethodError(
 	method reflect.Method,
 	invocations []*MethodCall,
 	argMatchers []*matcherWrapper,
+	stubbing *methodMatch,
 	recorder *methodRecorder,
 	err error,
 	stackTrace *StackTrace,
@@ -149,7 +155,10 @@ func (e *EnrichedReporter) ReportVerifyMethodError(
 	for i := range argMatchers {
 		args[i] = argMatchers[i].matcher.Description()
 	}
-	callStr := PrettyPrintMethodInvocation(tp, method, args)
+	callStr := prettyPrintNamedMethodInvocation(tp, e.cfg.Name, method, args)
+	if stubbing != nil {
+		callStr += describeStubbingAnswers(stubbing)
+	}
 
 	other := strings.Builder{}
 	calls := recorder.calls.GetCopy()
@@ -161,8 +170,9 @@ func (e *EnrichedReporter) ReportVerifyMethodError(
 		for i := range c.Values {
 			callArgs[i] = fmt.Sprintf("%v", c.Values[i])
 		}
-		pretty := PrettyPrintMethodInvocation(tp, c.Method, callArgs)
+		pretty := prettyPrintNamedMethodInvocation(tp, e.cfg.Name, c.Method, callArgs)
 		other.WriteString(fmt.Sprintf("\t\t%s at %s", pretty, c.StackTrace.CallerLine()))
+		other.WriteString(describeMismatches(argMatchers, c))
 		if j != len(calls)-1 {
 			other.WriteString("\n")
 		}
@@ -196,9 +206,7 @@ func (e *EnrichedReporter) ReportInvalidReturnValues(instanceType reflect.Type,
 	tp := method.Type
 	outTypesSB := strings.Builder{}
 
-	interfaceName := instanceType.Name()
-	methodName := method.Name
-	outTypesSB.WriteString(interfaceName + "." + methodName)
+	outTypesSB.WriteString(methodDisplayName(instanceType, e.cfg.Name, method))
 	outTypesSB.WriteString("(")
 	for i := 0; i < tp.NumIn(); i++ {
 		outTypesSB.WriteString(tp.In(i).Name())
@@ -229,7 +237,7 @@ func (e *EnrichedReporter) ReportInvalidReturnValues(instanceType reflect.Type,
 		outTypesSB.WriteString(")")
 	}
 
-	methodSig := prettyPrintMethodSignature(instanceType, method)
+	methodSig := prettyPrintMethodSignature(instanceType, e.cfg.Name, method)
 
 	e.StackTraceFatalf(`invalid return values
 expected:
@@ -239,6 +247,146 @@ got:
 `, methodSig, outTypesSB.String())
 }
 
+func (e *EnrichedReporter) ReportDelegateMethodNotFound(instanceType reflect.Type, method reflect.Method) {
+	methodSig := prettyPrintMethodSignature(instanceType, e.cfg.Name, method)
+	e.StackTraceFatalf(`Unable to call real method on spy:
+		%v
+	Only exported methods can be delegated to the real implementation.`, methodSig)
+}
+
+func (e *EnrichedReporter) ReportCallRealMethodWithoutDelegate(instanceType reflect.Type) {
+	e.StackTraceFatalf(`ThenCallRealMethod() can only be used on a spy, but %v mock has no real implementation.
+	Create the mock with Spy(ctrl, impl) instead of Mock(ctrl).`, mockDisplayName(instanceType, e.cfg.Name))
+}
+
+func (e *EnrichedReporter) ReportInvalidThenDoFunc(instanceType reflect.Type, method reflect.Method, fn reflect.Type) {
+	got := "nil"
+	if fn != nil {
+		got = fn.String()
+	}
+	e.StackTraceFatalf(`invalid ThenDo function for %v
+expected:
+	%v
+got:
+	%v
+`, methodDisplayName(instanceType, e.cfg.Name, method), method.Type.String(), got)
+}
+
+func (e *EnrichedReporter) ReportInvalidAnswer(instanceType reflect.Type, method reflect.Method, answer string, err error) {
+	e.StackTraceFatalf(`invalid %v for %v:
+		%v`, answer, methodDisplayName(instanceType, e.cfg.Name, method), err)
+}
+
+func (e *EnrichedReporter) ReportArgAnswerError(instanceType reflect.Type, call *MethodCall, err error) {
+	args := make([]string, len(call.Values))
+	for i := range call.Values {
+		args[i] = fmt.Sprintf("%v", call.Values[i])
+	}
+	e.StackTraceErrorf(call.StackTrace, true, `Unable to set arguments of call:
+		%v
+	Error:
+		%v`, prettyPrintNamedMethodInvocation(instanceType, e.cfg.Name, call.Method, args), err)
+}
+
+func (e *EnrichedReporter) ReportDefaultAnswerError(instanceType reflect.Type, call *MethodCall, err error) {
+	args := make([]string, len(call.Values))
+	for i := range call.Values {
+		args[i] = fmt.Sprintf("%v", call.Values[i])
+	}
+	e.StackTraceFatalf(`Default answer failed for unstubbed call:
+		%v
+	Error:
+		%v`, prettyPrintNamedMethodInvocation(instanceType, e.cfg.Name, call.Method, args), err)
+}
+
+func (e *EnrichedReporter) ReportUnstubbedCall(instanceType reflect.Type, call *MethodCall, methodMatches []*methodMatch) {
+	args := make([]string, len(call.Values))
+	for i := range call.Values {
+		args[i] = fmt.Sprintf("%v", call.Values[i])
+	}
+	callStr := prettyPrintNamedMethodInvocation(instanceType, e.cfg.Name, call.Method, args)
+	if len(methodMatches) == 0 {
+		e.StackTraceErrorf(call.StackTrace, true, `Unstubbed call:
+		%v
+	There are no stubbings for this method.`, callStr)
+		return
+	}
+	sb := strings.Builder{}
+	for i, mm := range methodMatches {
+		matcherArgs := make([]string, len(mm.matchers))
+		for j := range mm.matchers {
+			matcherArgs[j] = mm.matchers[j].matcher.Description()
+		}
+		pretty := prettyPrintNamedMethodInvocation(instanceType, e.cfg.Name, call.Method, matcherArgs)
+		pretty += describeStubbingAnswers(mm)
+		sb.WriteString(fmt.Sprintf("\t\t%s at %s", pretty, mm.stackTrace.CallerLine()))
+		sb.WriteString(describeMismatches(mm.matchers, call))
+		if i != len(methodMatches)-1 {
+			sb.WriteString("\n")
+		}
+	}
+	e.StackTraceErrorf(call.StackTrace, true, `Unstubbed call:
+		%v
+	Arguments do not match any of the stubbings for this method:
+%v`, callStr, sb.String())
+}
+
+func (e *EnrichedReporter) ReportInvalidStubbingLimit(n int) {
+	e.StackTraceFatalf("invalid stubbing limit: Times(%d), the limit must be positive", n)
+}
+
+func (e *EnrichedReporter) ReportStubExhausted(instanceType reflect.Type, call *MethodCall, mm *methodMatch) {
+	args := make([]string, len(call.Values))
+	for i := range call.Values {
+		args[i] = fmt.Sprintf("%v", call.Values[i])
+	}
+	matcherArgs := make([]string, len(mm.matchers))
+	for i := range mm.matchers {
+		matcherArgs[i] = mm.matchers[i].matcher.Description()
+	}
+	e.StackTraceErrorf(call.StackTrace, true, `stub exhausted after %d calls:
+		%v at %v
+	Unexpected call:
+		%v`,
+		mm.answerCount(),
+		prettyPrintNamedMethodInvocation(instanceType, e.cfg.Name, call.Method, matcherArgs),
+		mm.stackTrace.CallerLine(),
+		prettyPrintNamedMethodInvocation(instanceType, e.cfg.Name, call.Method, args),
+	)
+}
+
+// describeMismatches explains why arguments of the call do not match, for matchers that implement matchers.MismatchDescriber.
+func describeMismatches(m []*matcherWrapper, call *MethodCall) string {
+	if len(m) != len(call.Values) {
+		return ""
+	}
+	sb := strings.Builder{}
+	args := valueSliceToInterfaceSlice(call.Values)
+	for i := range m {
+		d, ok := m[i].matcher.(matchers.MismatchDescriber)
+		if !ok {
+			continue
+		}
+		if reason := d.DescribeMismatch(args, args[i]); reason != "" {
+			sb.WriteString(fmt.Sprintf("\n\t\t\targument %d: %s", i, reason))
+		}
+	}
+	return sb.String()
+}
+
+// describeStubbingAnswers returns a description of answers that are not apparent from return values, like panics.
+func describeStubbingAnswers(mm *methodMatch) string {
+	values := mm.panicValues()
+	if len(values) == 0 {
+		return ""
+	}
+	strs := make([]string, len(values))
+	for i := range values {
+		strs[i] = fmt.Sprintf("%v", values[i])
+	}
+	return fmt.Sprintf(" panics with: %s", strings.Join(strs, ", "))
+}
+
 func newEnrichedReporter(reporter matchers.ErrorReporter, cfg *config.MockConfig) *EnrichedReporter {
 	return &EnrichedReporter{
 		reporter: reporter,
@@ -246,13 +394,11 @@ func newEnrichedReporter(reporter matchers.ErrorReporter, cfg *config.MockConfig
 	}
 }
 
-func prettyPrintMethodSignature(interfaceType reflect.Type, method reflect.Method) string {
+func prettyPrintMethodSignature(interfaceType reflect.Type, mockName string, method reflect.Method) string {
 	var signature string
 
-	interfaceName := interfaceType.Name()
-	methodName := method.Name
 	methodType := method.Type
-	signature += interfaceName + "." + methodName
+	signature += methodDisplayName(interfaceType, mockName, method)
 
 	numParams := methodType.NumIn()
 	signature += "("
@@ -286,11 +432,38 @@ func prettyPrintMethodSignature(interfaceType reflect.Type, method reflect.Metho
 	return signature
 }
 
+// mockDisplayName returns the name of a mock as it is shown in reports.
+// Named mocks are shown by their name, other mocks by the name of their type.
+func mockDisplayName(instanceType reflect.Type, mockName string) string {
+	if mockName != "" {
+		return mockName
+	}
+	if instanceType.Name() == "" && instanceType.Kind() == reflect.Func {
+		return "func"
+	}
+	return instanceType.Name()
+}
+
+// methodDisplayName returns the name of a method as it is shown in reports.
+// Func mocks are shown by the name of the mock only.
+func methodDisplayName(instanceType reflect.Type, mockName string, method reflect.Method) string {
+	if instanceType.Kind() == reflect.Func {
+		return mockDisplayName(instanceType, mockName)
+	}
+	return mockDisplayName(instanceType, mockName) + "." + method.Name
+}
+
+// PrettyPrintMethodInvocation prints the invocation of a method, like Store.Get(a).
+// Reports of named mocks print the mock name instead of the interface name.
 func PrettyPrintMethodInvocation(interfaceType reflect.Type, method reflect.Method, args []string) string {
+	return prettyPrintNamedMethodInvocation(interfaceType, "", method, args)
+}
+
+// prettyPrintNamedMethodInvocation prints the invocation like PrettyPrintMethodInvocation,
+// except that the method is prefixed with the mock name instead of the interface name, if the name is set.
+func prettyPrintNamedMethodInvocation(interfaceType reflect.Type, mockName string, method reflect.Method, args []string) string {
 	sb := strings.Builder{}
-	interfaceName := interfaceType.Name()
-	methodName := method.Name
-	sb.WriteString(interfaceName + "." + methodName)
+	sb.WriteString(methodDisplayName(interfaceType, mockName, method))
 	sb.WriteRune('(')
 	for i, v := range args {
 		sb.WriteString(fmt.Sprintf("%v", v))
@@ -309,7 +482,7 @@ func (e *EnrichedReporter) ReportNoMoreInteractionsExpected(fatal bool, instance
 		for _, v := range c.Values {
 			args = append(args, fmt.Sprintf("%v", v))
 		}
-		s := PrettyPrintMethodInvocation(instanceType, c.Method, args)
+		s := prettyPrintNamedMethodInvocation(instanceType, e.cfg.Name, c.Method, args)
 		line := fmt.Sprintf("\t\t%s at %s", s, c.StackTrace.CallerLine())
 		sb.WriteString(line)
 		if i != len(calls)-1 {
@@ -333,3 +506,18 @@ func (e *EnrichedReporter) ReportUnexpectedMatcherDeclaration(m []*matcherWrappe
 %s
 	Matchers can only be used inside When() method call.`, sb.String())
 }
+
+func (e *EnrichedReporter) ReportInvalidMatcherComposition(combinator string, expected int, m []*matcherWrapper) {
+	sb := strings.Builder{}
+	for _, v := range m {
+		sb.WriteString(fmt.Sprintf("\n\t\t%v at %v", v.matcher.Description(), v.stackTrace.CallerLine()))
+	}
+	e.StackTraceFatalf(`Invalid use of %s: %d matchers expected, %d recorded:%s
+	All arguments of %s must be matchers, use Exact or Equal for plain values.
+	Captors can not be combined.`, combinator, expected, len(m), sb.String(), combinator)
+}
+
+func (e *EnrichedReporter) ReportInvalidMatcher(description string, stackTrace *StackTrace, err error) {
+	e.StackTraceFatalf(`Invalid matcher %s at %s:
+		%v`, description, stackTrace.CallerLine(), err)
+}
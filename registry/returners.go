@@ -1,6 +1,9 @@
 package registry
 
 import (
+	"reflect"
+
+	"github.com/ovechkin-dm/mockio/v2/config"
 	"github.com/ovechkin-dm/mockio/v2/matchers"
 )
 
@@ -16,6 +19,18 @@ func ToReturnerDouble[A any, B any](retAll matchers.ReturnerAll) matchers.Return
 	}
 }
 
+func ToReturnerTriple[A any, B any, C any](retAll matchers.ReturnerAll) matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: retAll,
+	}
+}
+
+func ToReturnerQuad[A any, B any, C any, D any](retAll matchers.ReturnerAll) matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: retAll,
+	}
+}
+
 type returnerDummyImpl struct{}
 
 func (r *returnerDummyImpl) ThenReturn(values ...any) matchers.ReturnerAll {
@@ -26,12 +41,76 @@ func (r *returnerDummyImpl) ThenAnswer(f matchers.Answer) matchers.ReturnerAll {
 	return r
 }
 
+func (r *returnerDummyImpl) ThenCallRealMethod() matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) ThenPanic(value any) matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) ThenDo(fn any) matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) ThenEmit(values ...any) matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) ThenEmitAsync(values ...any) matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) ThenYield(values ...any) matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) ThenSetArg(index int, value any) matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) ThenCopyToArg(index int, data []byte) matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) ThenSetVariadicArgs(values ...any) matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) RepeatLast() matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) Cycle() matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) FallbackToDefaults() matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) Only() matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) Times(n int) matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) Override() matchers.ReturnerAll {
+	return r
+}
+
+func (r *returnerDummyImpl) ThenAnswerInvocation(f matchers.InvocationAnswer) matchers.ReturnerAll {
+	return r
+}
+
 func (r *returnerDummyImpl) Verify(m matchers.MethodVerifier) {
 }
 
 type returnerAllImpl struct {
 	methodMatch *methodMatch
-	ctx         *mockContext
+	handler     *invocationHandler
 }
 
 type returnerSingleImpl[T any] struct {
@@ -53,6 +132,105 @@ func (r *returnerSingleImpl[T]) ThenAnswer(f func(args []any) T) matchers.Return
 	}
 }
 
+func (r *returnerSingleImpl[T]) ThenAnswerInvocation(f func(inv matchers.Invocation) T) matchers.ReturnerSingle[T] {
+	all := r.all.ThenAnswerInvocation(func(inv matchers.Invocation) []any {
+		return []any{f(inv)}
+	})
+	return &returnerSingleImpl[T]{
+		all: all,
+	}
+}
+
+func (r *returnerSingleImpl[T]) ThenCallRealMethod() matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.ThenCallRealMethod(),
+	}
+}
+
+func (r *returnerSingleImpl[T]) ThenPanic(value any) matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.ThenPanic(value),
+	}
+}
+
+func (r *returnerSingleImpl[T]) ThenDo(fn any) matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.ThenDo(fn),
+	}
+}
+
+func (r *returnerSingleImpl[T]) ThenEmit(values ...any) matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.ThenEmit(values...),
+	}
+}
+
+func (r *returnerSingleImpl[T]) ThenEmitAsync(values ...any) matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.ThenEmitAsync(values...),
+	}
+}
+
+func (r *returnerSingleImpl[T]) ThenYield(values ...any) matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.ThenYield(values...),
+	}
+}
+
+func (r *returnerSingleImpl[T]) ThenSetArg(index int, value any) matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.ThenSetArg(index, value),
+	}
+}
+
+func (r *returnerSingleImpl[T]) ThenCopyToArg(index int, data []byte) matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.ThenCopyToArg(index, data),
+	}
+}
+
+func (r *returnerSingleImpl[T]) ThenSetVariadicArgs(values ...any) matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.ThenSetVariadicArgs(values...),
+	}
+}
+
+func (r *returnerSingleImpl[T]) RepeatLast() matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.RepeatLast(),
+	}
+}
+
+func (r *returnerSingleImpl[T]) Cycle() matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.Cycle(),
+	}
+}
+
+func (r *returnerSingleImpl[T]) FallbackToDefaults() matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.FallbackToDefaults(),
+	}
+}
+
+func (r *returnerSingleImpl[T]) Only() matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.Only(),
+	}
+}
+
+func (r *returnerSingleImpl[T]) Times(n int) matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.Times(n),
+	}
+}
+
+func (r *returnerSingleImpl[T]) Override() matchers.ReturnerSingle[T] {
+	return &returnerSingleImpl[T]{
+		all: r.all.Override(),
+	}
+}
+
 func (r *returnerSingleImpl[T]) Verify(verifier matchers.MethodVerifier) {
 	r.all.Verify(verifier)
 }
@@ -77,10 +255,358 @@ func (r *returnerDoubleImpl[A, B]) ThenAnswer(f func(args []any) (A, B)) matcher
 	}
 }
 
+func (r *returnerDoubleImpl[A, B]) ThenAnswerInvocation(f func(inv matchers.Invocation) (A, B)) matchers.ReturnerDouble[A, B] {
+	all := r.all.ThenAnswerInvocation(func(inv matchers.Invocation) []any {
+		a, b := f(inv)
+		return []any{a, b}
+	})
+	return &returnerDoubleImpl[A, B]{
+		all: all,
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) ThenCallRealMethod() matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.ThenCallRealMethod(),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) ThenPanic(value any) matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.ThenPanic(value),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) ThenDo(fn any) matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.ThenDo(fn),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) ThenEmit(values ...any) matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.ThenEmit(values...),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) ThenEmitAsync(values ...any) matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.ThenEmitAsync(values...),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) ThenYield(values ...any) matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.ThenYield(values...),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) ThenSetArg(index int, value any) matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.ThenSetArg(index, value),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) ThenCopyToArg(index int, data []byte) matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.ThenCopyToArg(index, data),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) ThenSetVariadicArgs(values ...any) matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.ThenSetVariadicArgs(values...),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) RepeatLast() matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.RepeatLast(),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) Cycle() matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.Cycle(),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) FallbackToDefaults() matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.FallbackToDefaults(),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) Only() matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.Only(),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) Times(n int) matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.Times(n),
+	}
+}
+
+func (r *returnerDoubleImpl[A, B]) Override() matchers.ReturnerDouble[A, B] {
+	return &returnerDoubleImpl[A, B]{
+		all: r.all.Override(),
+	}
+}
+
 func (r *returnerDoubleImpl[A, B]) Verify(verifier matchers.MethodVerifier) {
 	r.all.Verify(verifier)
 }
 
+type returnerTripleImpl[A any, B any, C any] struct {
+	all matchers.ReturnerAll
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenReturn(a A, b B, c C) matchers.ReturnerTriple[A, B, C] {
+	return r.ThenAnswer(func(args []any) (A, B, C) {
+		return a, b, c
+	})
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenAnswer(f func(args []any) (A, B, C)) matchers.ReturnerTriple[A, B, C] {
+	all := r.all.ThenAnswer(func(args []any) []any {
+		a, b, c := f(args)
+		return []any{a, b, c}
+	})
+	return &returnerTripleImpl[A, B, C]{
+		all: all,
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenAnswerInvocation(f func(inv matchers.Invocation) (A, B, C)) matchers.ReturnerTriple[A, B, C] {
+	all := r.all.ThenAnswerInvocation(func(inv matchers.Invocation) []any {
+		a, b, c := f(inv)
+		return []any{a, b, c}
+	})
+	return &returnerTripleImpl[A, B, C]{
+		all: all,
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenCallRealMethod() matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.ThenCallRealMethod(),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenPanic(value any) matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.ThenPanic(value),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenDo(fn any) matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.ThenDo(fn),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenEmit(values ...any) matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.ThenEmit(values...),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenEmitAsync(values ...any) matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.ThenEmitAsync(values...),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenYield(values ...any) matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.ThenYield(values...),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenSetArg(index int, value any) matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.ThenSetArg(index, value),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenCopyToArg(index int, data []byte) matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.ThenCopyToArg(index, data),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) ThenSetVariadicArgs(values ...any) matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.ThenSetVariadicArgs(values...),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) RepeatLast() matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.RepeatLast(),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) Cycle() matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.Cycle(),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) FallbackToDefaults() matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.FallbackToDefaults(),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) Only() matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.Only(),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) Times(n int) matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.Times(n),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) Override() matchers.ReturnerTriple[A, B, C] {
+	return &returnerTripleImpl[A, B, C]{
+		all: r.all.Override(),
+	}
+}
+
+func (r *returnerTripleImpl[A, B, C]) Verify(verifier matchers.MethodVerifier) {
+	r.all.Verify(verifier)
+}
+
+type returnerQuadImpl[A any, B any, C any, D any] struct {
+	all matchers.ReturnerAll
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenReturn(a A, b B, c C, d D) matchers.ReturnerQuad[A, B, C, D] {
+	return r.ThenAnswer(func(args []any) (A, B, C, D) {
+		return a, b, c, d
+	})
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenAnswer(f func(args []any) (A, B, C, D)) matchers.ReturnerQuad[A, B, C, D] {
+	all := r.all.ThenAnswer(func(args []any) []any {
+		a, b, c, d := f(args)
+		return []any{a, b, c, d}
+	})
+	return &returnerQuadImpl[A, B, C, D]{
+		all: all,
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenAnswerInvocation(f func(inv matchers.Invocation) (A, B, C, D)) matchers.ReturnerQuad[A, B, C, D] {
+	all := r.all.ThenAnswerInvocation(func(inv matchers.Invocation) []any {
+		a, b, c, d := f(inv)
+		return []any{a, b, c, d}
+	})
+	return &returnerQuadImpl[A, B, C, D]{
+		all: all,
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenCallRealMethod() matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.ThenCallRealMethod(),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenPanic(value any) matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.ThenPanic(value),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenDo(fn any) matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.ThenDo(fn),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenEmit(values ...any) matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.ThenEmit(values...),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenEmitAsync(values ...any) matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.ThenEmitAsync(values...),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenYield(values ...any) matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.ThenYield(values...),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenSetArg(index int, value any) matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.ThenSetArg(index, value),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenCopyToArg(index int, data []byte) matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.ThenCopyToArg(index, data),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) ThenSetVariadicArgs(values ...any) matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.ThenSetVariadicArgs(values...),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) RepeatLast() matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.RepeatLast(),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) Cycle() matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.Cycle(),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) FallbackToDefaults() matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.FallbackToDefaults(),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) Only() matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.Only(),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) Times(n int) matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.Times(n),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) Override() matchers.ReturnerQuad[A, B, C, D] {
+	return &returnerQuadImpl[A, B, C, D]{
+		all: r.all.Override(),
+	}
+}
+
+func (r *returnerQuadImpl[A, B, C, D]) Verify(verifier matchers.MethodVerifier) {
+	r.all.Verify(verifier)
+}
+
 func (r *returnerAllImpl) ThenReturn(values ...any) matchers.ReturnerAll {
 	return r.ThenAnswer(makeReturnFunc(values))
 }
@@ -93,6 +619,133 @@ func (r *returnerAllImpl) ThenAnswer(f matchers.Answer) matchers.ReturnerAll {
 	return r
 }
 
+func (r *returnerAllImpl) ThenAnswerInvocation(f matchers.InvocationAnswer) matchers.ReturnerAll {
+	wrapper := &answerWrapper{
+		invocationAns: f,
+	}
+	r.methodMatch.addAnswer(wrapper)
+	return r
+}
+
+func (r *returnerAllImpl) ThenCallRealMethod() matchers.ReturnerAll {
+	if !r.handler.delegate.IsValid() {
+		r.handler.reporter.ReportCallRealMethodWithoutDelegate(r.handler.instanceType)
+		return r
+	}
+	wrapper := &answerWrapper{
+		callRealMethod: true,
+	}
+	r.methodMatch.addAnswer(wrapper)
+	return r
+}
+
+func (r *returnerAllImpl) ThenPanic(value any) matchers.ReturnerAll {
+	wrapper := &answerWrapper{
+		panics:     true,
+		panicValue: value,
+	}
+	r.methodMatch.addAnswer(wrapper)
+	return r
+}
+
+func (r *returnerAllImpl) ThenDo(fn any) matchers.ReturnerAll {
+	method := r.methodMatch.method
+	fv := reflect.ValueOf(fn)
+	if !fv.IsValid() || !sameSignature(fv.Type(), method.Type) || fv.IsNil() {
+		r.handler.reporter.ReportInvalidThenDoFunc(r.handler.instanceType, method, reflect.TypeOf(fn))
+		return r
+	}
+	wrapper := &answerWrapper{
+		do: fv,
+	}
+	r.methodMatch.addAnswer(wrapper)
+	return r
+}
+
+func (r *returnerAllImpl) ThenEmit(values ...any) matchers.ReturnerAll {
+	ans, err := newEmitAnswer(r.methodMatch.method, values)
+	return r.addStreamAnswer("ThenEmit", ans, err)
+}
+
+func (r *returnerAllImpl) ThenEmitAsync(values ...any) matchers.ReturnerAll {
+	ans, err := newEmitAsyncAnswer(r.methodMatch.method, values, r.handler.done)
+	return r.addStreamAnswer("ThenEmitAsync", ans, err)
+}
+
+func (r *returnerAllImpl) ThenYield(values ...any) matchers.ReturnerAll {
+	ans, err := newYieldAnswer(r.methodMatch.method, values)
+	return r.addStreamAnswer("ThenYield", ans, err)
+}
+
+func (r *returnerAllImpl) addStreamAnswer(answer string, ans matchers.Answer, err error) matchers.ReturnerAll {
+	if err != nil {
+		r.handler.reporter.ReportInvalidAnswer(r.handler.instanceType, r.methodMatch.method, answer, err)
+		return r
+	}
+	return r.ThenAnswer(ans)
+}
+
+func (r *returnerAllImpl) ThenSetArg(index int, value any) matchers.ReturnerAll {
+	effect, err := newSetArgEffect(r.methodMatch.method, len(r.methodMatch.matchers), index, value)
+	return r.addArgEffect("ThenSetArg", effect, err)
+}
+
+func (r *returnerAllImpl) ThenCopyToArg(index int, data []byte) matchers.ReturnerAll {
+	effect, err := newCopyToArgEffect(r.methodMatch.method, len(r.methodMatch.matchers), index, data)
+	return r.addArgEffect("ThenCopyToArg", effect, err)
+}
+
+func (r *returnerAllImpl) ThenSetVariadicArgs(values ...any) matchers.ReturnerAll {
+	effect, err := newSetVariadicArgsEffect(r.methodMatch.method, len(r.methodMatch.matchers), values)
+	return r.addArgEffect("ThenSetVariadicArgs", effect, err)
+}
+
+func (r *returnerAllImpl) addArgEffect(answer string, effect argEffect, err error) matchers.ReturnerAll {
+	if err != nil {
+		r.handler.reporter.ReportInvalidAnswer(r.handler.instanceType, r.methodMatch.method, answer, err)
+		return r
+	}
+	wrapper := &answerWrapper{
+		effects: []argEffect{effect},
+	}
+	r.methodMatch.addAnswer(wrapper)
+	return r
+}
+
+func (r *returnerAllImpl) RepeatLast() matchers.ReturnerAll {
+	r.methodMatch.setExhaustion(config.RepeatLastAnswer)
+	return r
+}
+
+func (r *returnerAllImpl) Cycle() matchers.ReturnerAll {
+	r.methodMatch.setExhaustion(config.CycleAnswers)
+	return r
+}
+
+func (r *returnerAllImpl) FallbackToDefaults() matchers.ReturnerAll {
+	r.methodMatch.setExhaustion(config.FallbackToDefaults)
+	return r
+}
+
+func (r *returnerAllImpl) Only() matchers.ReturnerAll {
+	r.methodMatch.setExhaustion(config.FailWhenExhausted)
+	return r
+}
+
+func (r *returnerAllImpl) Times(n int) matchers.ReturnerAll {
+	if n <= 0 {
+		r.handler.reporter.ReportInvalidStubbingLimit(n)
+		return r
+	}
+	r.methodMatch.setLimit(int64(n))
+	return r
+}
+
+func (r *returnerAllImpl) Override() matchers.ReturnerAll {
+	r.handler.overrideStubbing(r.methodMatch)
+	return r
+}
+
 func (r *returnerAllImpl) Verify(verifier matchers.MethodVerifier) {
 	r.methodMatch.verifiers = append(r.methodMatch.verifiers, verifier)
 }
@@ -103,13 +756,22 @@ func makeReturnFunc(values []any) matchers.Answer {
 	}
 }
 
-func NewReturnerAll(ctx *mockContext, data *methodMatch) matchers.ReturnerAll {
+func NewReturnerAll(handler *invocationHandler, data *methodMatch) matchers.ReturnerAll {
 	return &returnerAllImpl{
 		methodMatch: data,
-		ctx:         ctx,
+		handler:     handler,
 	}
 }
 
 func NewEmptyReturner() matchers.ReturnerAll {
 	return &returnerDummyImpl{}
 }
+
+type stubberImpl[T any] struct {
+	answer *answerWrapper
+}
+
+func (s *stubberImpl[T]) When(mock T) T {
+	StubMethod(mock, s.answer)
+	return mock
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/ovechkin-dm/mockio/v2/config"
 	"github.com/ovechkin-dm/mockio/v2/matchers"
 	"github.com/ovechkin-dm/mockio/v2/threadlocal"
 	"github.com/ovechkin-dm/mockio/v2/utils"
@@ -18,6 +19,24 @@ type fiberState struct {
 	whenCall        *MethodCall
 	whenAnswer      *answerWrapper
 	whenMethodMatch *methodMatch
+	stubState       bool
+	stubAnswer      *answerWrapper
+	invalidMatchers func(reporter *EnrichedReporter)
+	deepStubChain   *deepStubLink
+}
+
+// deepStub is a child mock returned by a method of a mock with deep stubs.
+type deepStub struct {
+	value   reflect.Value
+	handler matchers.Handler
+}
+
+// deepStubLink is a call that returned a deep stub, linked to the call that returned its parent.
+// Calls of a chain like client.Users().Get("id") are marked as stub definitions when the last call of the chain is stubbed.
+type deepStubLink struct {
+	handler matchers.Handler
+	call    *MethodCall
+	prev    *deepStubLink
 }
 
 type mockContext struct {
@@ -30,62 +49,143 @@ type methodRecorder struct {
 	methodMatches *utils.SyncList[*methodMatch]
 	calls         *utils.SyncList[*MethodCall]
 	methodType    reflect.Method
+	lock          sync.Mutex
+	deepStubs     map[int]*deepStub
 }
 
 type methodMatch struct {
+	method      reflect.Method
 	matchers    []*matcherWrapper
 	unanswered  []*answerWrapper
 	answered    []*answerWrapper
 	lock        sync.Mutex
 	lastAnswer  *answerWrapper
 	invocations int64
+	limit       int64
 	verifiers   []matchers.MethodVerifier
 	stackTrace  *StackTrace
+	exhaustion  config.ExhaustionPolicy
 }
 
-func (m *methodMatch) popAnswer() *answerWrapper {
+// popAnswer returns the next answer of the stubbing, or nil if there is no answer for the call.
+// Once all answers were used, the next answer is chosen by the exhaustion policy of the stubbing.
+// The returned flag is true if the policy fails calls after exhaustion.
+func (m *methodMatch) popAnswer() (*answerWrapper, bool) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	atomic.AddInt64(&m.invocations, 1)
+	if len(m.unanswered) == 0 && len(m.answered) != 0 {
+		switch m.exhaustion {
+		case config.CycleAnswers:
+			m.unanswered = m.answered
+			m.answered = make([]*answerWrapper, 0)
+		case config.FallbackToDefaults:
+			return nil, false
+		case config.FailWhenExhausted:
+			return nil, true
+		}
+	}
 	if len(m.unanswered) == 0 {
-		return m.lastAnswer
+		return m.lastAnswer, false
 	}
 	last := m.unanswered[0]
 	m.unanswered = m.unanswered[1:]
 	m.answered = append(m.answered, last)
 	m.lastAnswer = last
-	return last
+	return last, false
+}
+
+// tryUse counts a call answered by the stubbing.
+// It returns false if the usage limit of the stubbing was reached, so the call must be answered by another stubbing.
+// The returned index is the zero-based index of the call among calls answered by the stubbing.
+func (m *methodMatch) tryUse() (int, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.limit > 0 && atomic.LoadInt64(&m.invocations) >= m.limit {
+		return 0, false
+	}
+	return int(atomic.AddInt64(&m.invocations, 1) - 1), true
+}
+
+// releaseUse reverts tryUse for a call that turned out to be a stub definition.
+func (m *methodMatch) releaseUse() {
+	atomic.AddInt64(&m.invocations, -1)
+}
+
+func (m *methodMatch) setLimit(limit int64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.limit = limit
 }
 
+func (m *methodMatch) setExhaustion(policy config.ExhaustionPolicy) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.exhaustion = policy
+}
+
+func (m *methodMatch) answerCount() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return len(m.answered) + len(m.unanswered)
+}
+
+// addAnswer adds the answer to the end of the answer sequence.
+// Argument effects that were added without an answer are merged into the added answer.
 func (m *methodMatch) addAnswer(wrapper *answerWrapper) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
+	if n := len(m.unanswered); n > 0 && !m.unanswered[n-1].hasAnswer() {
+		wrapper.effects = append(m.unanswered[n-1].effects, wrapper.effects...)
+		m.unanswered[n-1] = wrapper
+		return
+	}
 	m.unanswered = append(m.unanswered, wrapper)
 }
 
-func (m *methodMatch) putBackAnswer(wrapper *answerWrapper) {
+// panicValues returns values of all panicking answers of the stubbing.
+func (m *methodMatch) panicValues() []any {
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	atomic.AddInt64(&m.invocations, -1)
-	foundIdx := -1
-	for i := len(m.answered) - 1; i >= 0; i-- {
-		if wrapper == m.answered[i] {
-			foundIdx = i
-			break
+	result := make([]any, 0)
+	for _, answers := range [][]*answerWrapper{m.answered, m.unanswered} {
+		for _, a := range answers {
+			if a.panics {
+				result = append(result, a.panicValue)
+			}
 		}
 	}
-	if foundIdx == -1 {
+	return result
+}
+
+// putBackAnswer returns the answer of a call that turned out to be a stub definition to the front of the queue.
+func (m *methodMatch) putBackAnswer(wrapper *answerWrapper) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	n := len(m.answered)
+	if n == 0 || m.answered[n-1] != wrapper {
 		return
 	}
-	for i := foundIdx; i < len(m.unanswered)-1; i++ {
-		m.answered[i] = m.answered[i+1]
+	m.answered = m.answered[:n-1]
+	m.unanswered = append([]*answerWrapper{wrapper}, m.unanswered...)
+	m.lastAnswer = nil
+	if n > 1 {
+		m.lastAnswer = m.answered[n-2]
 	}
-	m.answered = m.answered[0 : len(m.answered)-1]
-	m.unanswered = append(m.unanswered, wrapper)
 }
 
 type answerWrapper struct {
-	ans matchers.Answer
+	ans            matchers.Answer
+	callRealMethod bool
+	panics         bool
+	panicValue     any
+	do             reflect.Value
+	effects        []argEffect
+	invocationAns  matchers.InvocationAnswer
+}
+
+// hasAnswer reports whether the wrapper defines return values of a call, and not only argument effects.
+func (a *answerWrapper) hasAnswer() bool {
+	return a.ans != nil || a.invocationAns != nil || a.callRealMethod || a.panics || a.do.IsValid()
 }
 
 type matcherWrapper struct {
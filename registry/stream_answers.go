@@ -0,0 +1,216 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/ovechkin-dm/mockio/v2/matchers"
+)
+
+// newEmitAnswer creates an answer that returns a fresh channel on every call.
+// The channel is pre-filled with the values and closed, so receivers get all values and then observe the close.
+// If the method also returns a channel of errors, error values that can not be sent to the value channel are sent to it.
+func newEmitAnswer(method reflect.Method, values []any) (matchers.Answer, error) {
+	tp := method.Type
+	chans, targets, err := emitTargets(method, values)
+	if err != nil {
+		return nil, err
+	}
+	return func(args []any) []any {
+		result := zeroResults(tp)
+		for _, idx := range chans {
+			items := make([]reflect.Value, 0)
+			for i := range values {
+				if targets[i] == idx {
+					items = append(items, valueOrZero(values[i], tp.Out(idx).Elem()))
+				}
+			}
+			ch := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, tp.Out(idx).Elem()), len(items))
+			for _, item := range items {
+				ch.Send(item)
+			}
+			ch.Close()
+			result[idx] = ch.Convert(tp.Out(idx)).Interface()
+		}
+		return result
+	}, nil
+}
+
+// newEmitAsyncAnswer creates an answer that returns fresh unbuffered channels on every call.
+// A goroutine sends the values in order and closes the channels after the last value.
+// The goroutine stops sending and closes the channels early when a context.Context argument of the call is done,
+// or when done is closed at the end of the test, so abandoned receivers do not leak it.
+func newEmitAsyncAnswer(method reflect.Method, values []any, done <-chan struct{}) (matchers.Answer, error) {
+	tp := method.Type
+	chans, targets, err := emitTargets(method, values)
+	if err != nil {
+		return nil, err
+	}
+	return func(args []any) []any {
+		result := zeroResults(tp)
+		out := make(map[int]reflect.Value, len(chans))
+		for _, idx := range chans {
+			ch := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, tp.Out(idx).Elem()), 0)
+			out[idx] = ch
+			result[idx] = ch.Convert(tp.Out(idx)).Interface()
+		}
+		cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)}}
+		for _, arg := range args {
+			if ctx, ok := arg.(context.Context); ok && ctx != nil {
+				cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+			}
+		}
+		send := len(cases)
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectSend})
+		go func() {
+			defer func() {
+				for _, ch := range out {
+					ch.Close()
+				}
+			}()
+			for i, v := range values {
+				cases[send].Chan = out[targets[i]]
+				cases[send].Send = valueOrZero(v, tp.Out(targets[i]).Elem())
+				if chosen, _, _ := reflect.Select(cases); chosen != send {
+					return
+				}
+			}
+		}()
+		return result
+	}, nil
+}
+
+// emitTargets checks that the method returns a value channel and an optional error channel,
+// and returns indexes of the channel outputs and the index of the output that receives each value.
+func emitTargets(method reflect.Method, values []any) ([]int, []int, error) {
+	tp := method.Type
+	chans := make([]int, 0)
+	for i := 0; i < tp.NumOut(); i++ {
+		if tp.Out(i).Kind() == reflect.Chan {
+			chans = append(chans, i)
+		}
+	}
+	if len(chans) == 0 {
+		return nil, nil, fmt.Errorf("method %s does not return a channel", method.Name)
+	}
+	if len(chans) > 2 || len(chans) == 2 && tp.Out(chans[1]).Elem() != errorType {
+		return nil, nil, fmt.Errorf("method %s returns several channels, only a value channel followed by an error channel is supported", method.Name)
+	}
+	for _, idx := range chans {
+		if tp.Out(idx).ChanDir()&reflect.RecvDir == 0 {
+			return nil, nil, fmt.Errorf("return value %d of type %s is a send-only channel", idx, tp.Out(idx).String())
+		}
+	}
+	targets := make([]int, len(values))
+	for i, v := range values {
+		switch {
+		case isAssignable(v, tp.Out(chans[0]).Elem()):
+			targets[i] = chans[0]
+		case len(chans) == 2 && isAssignable(v, errorType):
+			targets[i] = chans[1]
+		default:
+			return nil, nil, fmt.Errorf("value %d of type %s can not be sent to channel of type %s", i, describeValue(reflect.ValueOf(v)), tp.Out(chans[0]).String())
+		}
+	}
+	return chans, targets, nil
+}
+
+// newYieldAnswer creates an answer that returns an iterator over the values.
+// Iterators are functions of shape func(yield func(V) bool) or func(yield func(K, V) bool), such as iter.Seq and iter.Seq2.
+// For two-value iterators the values are consumed in pairs.
+// The iterator stops as soon as yield returns false, so early break is respected.
+func newYieldAnswer(method reflect.Method, values []any) (matchers.Answer, error) {
+	tp := method.Type
+	seqIdx := -1
+	for i := 0; i < tp.NumOut(); i++ {
+		if _, ok := yieldParams(tp.Out(i)); !ok {
+			continue
+		}
+		if seqIdx != -1 {
+			return nil, fmt.Errorf("method %s returns several iterators", method.Name)
+		}
+		seqIdx = i
+	}
+	if seqIdx == -1 {
+		return nil, fmt.Errorf("method %s does not return an iterator", method.Name)
+	}
+	seqType := tp.Out(seqIdx)
+	params, _ := yieldParams(seqType)
+	if len(values)%len(params) != 0 {
+		return nil, fmt.Errorf("iterator of type %s yields pairs, got %d values", seqType.String(), len(values))
+	}
+	items := make([]reflect.Value, len(values))
+	for i, v := range values {
+		param := params[i%len(params)]
+		if !isAssignable(v, param) {
+			return nil, fmt.Errorf("value %d of type %s can not be yielded by iterator of type %s", i, describeValue(reflect.ValueOf(v)), seqType.String())
+		}
+		items[i] = valueOrZero(v, param)
+	}
+	return func(args []any) []any {
+		result := zeroResults(tp)
+		seq := reflect.MakeFunc(seqType, func(in []reflect.Value) []reflect.Value {
+			yield := in[0]
+			for i := 0; i < len(items); i += len(params) {
+				if !yield.Call(items[i : i+len(params)])[0].Bool() {
+					break
+				}
+			}
+			return nil
+		})
+		result[seqIdx] = seq.Interface()
+		return result
+	}, nil
+}
+
+// yieldParams returns parameter types of the yield function if tp is an iterator type.
+func yieldParams(tp reflect.Type) ([]reflect.Type, bool) {
+	if tp.Kind() != reflect.Func || tp.NumIn() != 1 || tp.NumOut() != 0 {
+		return nil, false
+	}
+	yield := tp.In(0)
+	if yield.Kind() != reflect.Func || yield.IsVariadic() || yield.NumIn() < 1 || yield.NumIn() > 2 {
+		return nil, false
+	}
+	if yield.NumOut() != 1 || yield.Out(0).Kind() != reflect.Bool {
+		return nil, false
+	}
+	params := make([]reflect.Type, yield.NumIn())
+	for i := range params {
+		params[i] = yield.In(i)
+	}
+	return params, true
+}
+
+func isAssignable(value any, tp reflect.Type) bool {
+	if value == nil {
+		return isNillable(tp)
+	}
+	return reflect.TypeOf(value).AssignableTo(tp)
+}
+
+func isNillable(tp reflect.Type) bool {
+	switch tp.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+func valueOrZero(value any, tp reflect.Type) reflect.Value {
+	result := reflect.New(tp).Elem()
+	if value != nil {
+		result.Set(reflect.ValueOf(value))
+	}
+	return result
+}
+
+func zeroResults(tp reflect.Type) []any {
+	result := make([]any, tp.NumOut())
+	for i := range result {
+		result[i] = reflect.Zero(tp.Out(i)).Interface()
+	}
+	return result
+}
@@ -5,6 +5,9 @@ import (
 	"reflect"
 	"runtime/debug"
 	"strings"
+	"unsafe"
+
+	"github.com/ovechkin-dm/mockio/v2/matchers"
 )
 
 const (
@@ -13,8 +16,150 @@ const (
 	TestPackageName = "github.com/ovechkin-dm/mockio/v2/tests"
 	DebugPackage    = "runtime/debug.Stack()"
 	GOIDPackageName = "github.com/petermattis/goid"
+	FuncMethodName  = "Call"
 )
 
+// funcMethod describes the call of a func mock as a method, so it can be recorded like an interface method.
+func funcMethod(tp reflect.Type) reflect.Method {
+	return reflect.Method{
+		Name: FuncMethodName,
+		Type: tp,
+	}
+}
+
+// methodsOf returns methods of the mocked type.
+// A func type is represented by a single method.
+func methodsOf(tp reflect.Type) []reflect.Method {
+	if tp.Kind() == reflect.Func {
+		return []reflect.Method{funcMethod(tp)}
+	}
+	result := make([]reflect.Method, tp.NumMethod())
+	for i := range result {
+		result[i] = tp.Method(i)
+	}
+	return result
+}
+
+// funcValuePointer returns the address of the closure behind a func value.
+// Unlike reflect.Value.Pointer, it is unique for every func created with reflect.MakeFunc.
+func funcValuePointer(f any) unsafe.Pointer {
+	return (*[2]unsafe.Pointer)(unsafe.Pointer(&f))[1]
+}
+
+// callFunc calls fn with arguments of a call to the method of type tp.
+// Variadic arguments are packed back into a slice, since call values are stored flattened.
+func callFunc(fn reflect.Value, tp reflect.Type, values []reflect.Value) []reflect.Value {
+	if !tp.IsVariadic() {
+		return fn.Call(values)
+	}
+	numFixed := tp.NumIn() - 1
+	args := make([]reflect.Value, 0, tp.NumIn())
+	args = append(args, values[:numFixed]...)
+	variadic := reflect.MakeSlice(tp.In(numFixed), 0, len(values)-numFixed)
+	variadic = reflect.Append(variadic, values[numFixed:]...)
+	args = append(args, variadic)
+	return fn.CallSlice(args)
+}
+
+// sameSignature reports whether func types have identical parameters and results.
+// Unlike type equality, it ignores the names of func types.
+func sameSignature(a reflect.Type, b reflect.Type) bool {
+	if a.Kind() != reflect.Func || b.Kind() != reflect.Func {
+		return false
+	}
+	if a.NumIn() != b.NumIn() || a.NumOut() != b.NumOut() || a.IsVariadic() != b.IsVariadic() {
+		return false
+	}
+	for i := 0; i < a.NumIn(); i++ {
+		if a.In(i) != b.In(i) {
+			return false
+		}
+	}
+	for i := 0; i < a.NumOut(); i++ {
+		if a.Out(i) != b.Out(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameMatchers reports whether stubbings with the given matchers accept the same calls.
+func sameMatchers(a []*matcherWrapper, b []*matcherWrapper) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameMatcher(a[i].matcher, b[i].matcher) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameMatcher reports whether the matchers accept the same values.
+// Matchers are compared by their kinds and descriptions, by expected values for equality matchers,
+// and by identities for matchers that have them.
+// Combined matchers are the same if their children are.
+func sameMatcher(a matchers.Matcher[any], b matchers.Matcher[any]) bool {
+	sa := matcherSource(a)
+	sb := matcherSource(b)
+	if matcherKind(sa) != matcherKind(sb) || a.Description() != b.Description() {
+		return false
+	}
+	switch m := sa.(type) {
+	case *combinedMatcher:
+		other, ok := sb.(*combinedMatcher)
+		if !ok || len(m.children) != len(other.children) {
+			return false
+		}
+		for i := range m.children {
+			if !sameMatcher(m.children[i], other.children[i]) {
+				return false
+			}
+		}
+		return true
+	case valuedMatcher:
+		other, ok := sb.(valuedMatcher)
+		if !ok {
+			return false
+		}
+		va, oka := m.expectedValue()
+		vb, okb := other.expectedValue()
+		if !oka || !okb {
+			return oka == okb
+		}
+		return reflect.TypeOf(va) == reflect.TypeOf(vb) && reflect.DeepEqual(va, vb)
+	case identifiedMatcher:
+		other, ok := sb.(identifiedMatcher)
+		return ok && m.identity() == other.identity()
+	default:
+		return true
+	}
+}
+
+// matcherKind returns the name of the matcher type without type arguments,
+// so that typed matchers and implicit matchers of plain arguments can be the same.
+func matcherKind(m any) string {
+	tp := reflect.TypeOf(m)
+	if tp.Kind() == reflect.Pointer {
+		tp = tp.Elem()
+	}
+	name := tp.Name()
+	if idx := strings.Index(name, "["); idx != -1 {
+		name = name[:idx]
+	}
+	return tp.PkgPath() + "." + name
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// isDeepStubbable reports whether a mock can be created in place of a nil value of the type,
+// like a child mock of deep stubs or a mock injected into a field.
+// Errors and empty interfaces are excluded, since a non-nil value changes their meaning.
+func isDeepStubbable(tp reflect.Type) bool {
+	return tp.Kind() == reflect.Interface && tp.NumMethod() > 0 && tp != errorType
+}
+
 func createDefaultReturnValues(m reflect.Method) []reflect.Value {
 	result := make([]reflect.Value, m.Type.NumOut())
 	for i := 0; i < m.Type.NumOut(); i++ {
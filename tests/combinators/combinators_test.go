@@ -0,0 +1,96 @@
+package combinators
+
+import (
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Greeter interface {
+	Greet(name string) string
+	Pair(a string, b int) string
+}
+
+func TestNot(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Greeter](ctrl)
+	WhenSingle(m.Greet(Not(Substring("admin")))).ThenReturn("hello")
+	r.AssertEqual("hello", m.Greet("john"))
+	r.AssertEqual("", m.Greet("superadmin"))
+	r.AssertNoError()
+}
+
+func TestAnd(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Greeter](ctrl)
+	WhenSingle(m.Greet(And(NotEqual(""), Not(Substring("admin"))))).ThenReturn("hello")
+	r.AssertEqual("hello", m.Greet("john"))
+	r.AssertEqual("", m.Greet(""))
+	r.AssertEqual("", m.Greet("admin"))
+	r.AssertNoError()
+}
+
+func TestOr(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Greeter](ctrl)
+	WhenSingle(m.Greet(Or(Regex("^a"), Substring("b")))).ThenReturn("hello")
+	r.AssertEqual("hello", m.Greet("alice"))
+	r.AssertEqual("hello", m.Greet("bob"))
+	r.AssertEqual("", m.Greet("carol"))
+	r.AssertNoError()
+}
+
+func TestAllOfAnyOf(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Greeter](ctrl)
+	WhenSingle(m.Greet(AllOf(Regex("^a"), Substring("l"), Not(Substring("x"))))).ThenReturn("all")
+	WhenSingle(m.Greet(AnyOf(Exact("bob"), Exact("carol"), Regex("^d")))).ThenReturn("any")
+	r.AssertEqual("all", m.Greet("alice"))
+	r.AssertEqual("", m.Greet("alex"))
+	r.AssertEqual("any", m.Greet("carol"))
+	r.AssertEqual("any", m.Greet("dave"))
+	r.AssertEqual("", m.Greet("eve"))
+	r.AssertNoError()
+}
+
+func TestCombinatorWithOtherArgs(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Greeter](ctrl)
+	WhenSingle(m.Pair(Or(Exact("a"), Exact("b")), Not(Exact(0)))).ThenReturn("ok")
+	r.AssertEqual("ok", m.Pair("b", 1))
+	r.AssertEqual("", m.Pair("b", 0))
+	r.AssertEqual("", m.Pair("c", 1))
+	r.AssertNoError()
+}
+
+func TestCombinedDescription(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Greeter](ctrl)
+	Verify(m, Once()).Greet(And(Regex("^a"), Not(Substring("admin"))))
+	r.AssertErrorContains(r.GetError(), "And(Regex(^a), Not(Substring(admin)))")
+}
+
+func TestCombinatorWithPlainValue(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Greeter](ctrl)
+	WhenSingle(m.Greet(Or("a", "b"))).ThenReturn("hello")
+	r.AssertErrorContains(r.GetError(), "Invalid use of Or: 2 matchers expected, 0 recorded")
+}
+
+func TestCombinatorWithCaptor(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Greeter](ctrl)
+	c := Captor[string]()
+	WhenSingle(m.Greet(Not(c.Capture()))).ThenReturn("hello")
+	r.AssertErrorContains(r.GetError(), "Captors can not be combined")
+}
@@ -0,0 +1,104 @@
+package deepstubs
+
+import (
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type User struct {
+	Name string
+}
+
+type UserService interface {
+	Get(id string) (*User, error)
+}
+
+type Client interface {
+	Users() UserService
+	Fail() error
+	Value() any
+}
+
+func TestDeepStubsDisabled(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Client](ctrl)
+	r.AssertEqual(nil, m.Users())
+}
+
+func TestDeepStubsChain(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.DeepStubs())
+	m := Mock[Client](ctrl)
+	u := &User{Name: "bob"}
+	WhenDouble(m.Users().Get("id")).ThenReturn(u, nil)
+	res, err := m.Users().Get("id")
+	r.AssertEqual(u, res)
+	r.AssertEqual(nil, err)
+	r.AssertNoError()
+}
+
+func TestDeepStubsSameChild(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.DeepStubs())
+	m := Mock[Client](ctrl)
+	r.AssertEqual(true, m.Users() == m.Users())
+}
+
+func TestDeepStubsVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.DeepStubs())
+	m := Mock[Client](ctrl)
+	_, _ = m.Users().Get("id")
+	Verify(m.Users(), Once()).Get("id")
+	Verify(m, Times(2)).Users()
+	r.AssertNoError()
+}
+
+func TestDeepStubsStubbedParent(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.DeepStubs())
+	m := Mock[Client](ctrl)
+	other := Mock[UserService](ctrl)
+	WhenSingle(m.Users()).ThenReturn(other)
+	r.AssertEqual(other, m.Users())
+}
+
+func TestDeepStubsSkipErrorAndAny(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.DeepStubs())
+	m := Mock[Client](ctrl)
+	r.AssertEqual(nil, m.Fail())
+	r.AssertEqual(nil, m.Value())
+}
+
+func TestDeepStubsStrictVerifyChain(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.DeepStubs(), mockopts.StrictVerify())
+	m := Mock[Client](ctrl)
+	u := &User{Name: "bob"}
+	WhenDouble(m.Users().Get("id")).ThenReturn(u, nil)
+	res, _ := m.Users().Get("id")
+	r.AssertEqual(u, res)
+	Verify(m, Once()).Users()
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
+
+func TestDeepStubsChainOnlyMarksStubbingCalls(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.DeepStubs(), mockopts.StrictVerify())
+	m := Mock[Client](ctrl)
+	users := m.Users()
+	_ = m.Fail()
+	WhenDouble(users.Get("id")).ThenReturn(nil, nil)
+	_, _ = m.Users().Get("id")
+	Verify(m, Once()).Fail()
+	Verify(m, Times(2)).Users()
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
@@ -0,0 +1,144 @@
+package defaultanswer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Store interface {
+	List() ([]string, error)
+	Index() map[string]int
+	Events() chan int
+	Updates() <-chan int
+	Count() int
+}
+
+type Builder interface {
+	WithName(name string) Builder
+	WithAge(age int) Builder
+	Build() string
+}
+
+func TestZeroValuesByDefault(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Store](ctrl)
+	list, err := m.List()
+	r.AssertEqual(true, list == nil)
+	r.AssertEqual(nil, err)
+	r.AssertNoError()
+}
+
+func TestReturnEmptyValues(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.ReturnEmptyValues())
+	m := Mock[Store](ctrl)
+	list, err := m.List()
+	r.AssertEqual([]string{}, list)
+	r.AssertEqual(nil, err)
+	r.AssertEqual(map[string]int{}, m.Index())
+	r.AssertEqual(true, m.Events() != nil)
+	r.AssertEqual(0, m.Count())
+	r.AssertNoError()
+}
+
+func TestReturnEmptyValuesReceiveOnlyChannel(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.ReturnEmptyValues())
+	m := Mock[Store](ctrl)
+	ch := m.Updates()
+	r.AssertEqual(true, ch != nil)
+	r.AssertEqual(0, cap(ch))
+	r.AssertNoError()
+}
+
+func TestReturnErrors(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.ReturnErrors())
+	m := Mock[Store](ctrl)
+	list, err := m.List()
+	r.AssertEqual(true, list == nil)
+	r.AssertEqual(true, errors.Is(err, mockopts.ErrUnstubbedCall))
+	r.AssertNoError()
+}
+
+func TestReturnErrorsStubbed(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.ReturnErrors())
+	m := Mock[Store](ctrl)
+	WhenDouble(m.List()).ThenReturn([]string{"a"}, nil)
+	list, err := m.List()
+	r.AssertEqual([]string{"a"}, list)
+	r.AssertEqual(nil, err)
+	r.AssertNoError()
+}
+
+func TestReturnSelf(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.ReturnSelf())
+	m := Mock[Builder](ctrl)
+	WhenSingle(m.Build()).ThenReturn("built")
+	res := m.WithName("bob").WithAge(10).Build()
+	r.AssertEqual("built", res)
+	Verify(m, Once()).WithName("bob")
+	r.AssertNoError()
+}
+
+func TestFailOnUnstubbedCall(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[Store](ctrl)
+	_ = m.Count()
+	r.AssertError()
+	r.AssertEqual(1, r.GetFatalCount())
+	r.TriggerCleanup()
+	r.AssertEqual(1, r.GetFatalCount())
+}
+
+func TestFailOnUnstubbedCallWithMatchers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[Builder](ctrl)
+	WhenSingle(m.WithName(AnyString())).ThenReturn(nil)
+	_ = m.WithName("bob")
+	r.AssertNoError()
+}
+
+func TestFailOnUnstubbedCallReplacesDefaultAnswer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.ReturnEmptyValues(), mockopts.FailOnUnstubbedCall())
+	m := Mock[Store](ctrl)
+	_ = m.Count()
+	r.TriggerCleanup()
+	r.AssertErrorContains(r.GetError(), "Unstubbed call")
+}
+
+func TestCustomDefaultAnswer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.WithDefaultAnswer(func(method reflect.Method, args []any) []any {
+		if method.Name == "Count" {
+			return []any{42}
+		}
+		return make([]any, method.Type.NumOut())
+	}))
+	m := Mock[Store](ctrl)
+	r.AssertEqual(42, m.Count())
+	r.AssertEqual(true, m.Index() == nil)
+	r.AssertNoError()
+}
+
+func TestCustomDefaultAnswerInvalid(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.WithDefaultAnswer(func(method reflect.Method, args []any) []any {
+		return []any{"invalid"}
+	}))
+	m := Mock[Store](ctrl)
+	r.AssertEqual(0, m.Count())
+	r.AssertError()
+}
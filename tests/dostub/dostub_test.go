@@ -0,0 +1,132 @@
+package dostub
+
+import (
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Msg struct {
+	Body string
+}
+
+type Publisher interface {
+	Publish(msg Msg)
+	Set(key string, value int)
+	Close()
+	Count() int
+}
+
+type Callback func(value string)
+
+func catch(f func()) (result any) {
+	defer func() {
+		result = recover()
+	}()
+	f()
+	return nil
+}
+
+func TestDoAnswer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Publisher](ctrl)
+	published := make([]Msg, 0)
+	DoAnswer[Publisher](func(args []any) []any {
+		published = append(published, args[0].(Msg))
+		return nil
+	}).When(m).Publish(Any[Msg]())
+	m.Publish(Msg{Body: "a"})
+	m.Publish(Msg{Body: "b"})
+	r.AssertEqual([]Msg{{Body: "a"}, {Body: "b"}}, published)
+	r.AssertNoError()
+}
+
+func TestDoAnswerExactValues(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Publisher](ctrl)
+	calls := 0
+	DoAnswer[Publisher](func(args []any) []any {
+		calls++
+		return nil
+	}).When(m).Set("a", 1)
+	m.Set("a", 1)
+	m.Set("a", 2)
+	r.AssertEqual(1, calls)
+	r.AssertNoError()
+}
+
+func TestDoAnswerWithReturnValue(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Publisher](ctrl)
+	DoAnswer[Publisher](func(args []any) []any {
+		return []any{42}
+	}).When(m).Count()
+	r.AssertEqual(42, m.Count())
+	r.AssertNoError()
+}
+
+func TestDoPanic(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Publisher](ctrl)
+	DoPanic[Publisher]("closed twice").When(m).Close()
+	r.AssertEqual("closed twice", catch(m.Close))
+	Verify(m, Once()).Close()
+	r.AssertNoError()
+}
+
+func TestDoNothing(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.StrictVerify())
+	m := Mock[Publisher](ctrl)
+	DoNothing[Publisher]().When(m).Set(AnyString(), AnyInt())
+	DoNothing[Publisher]().When(m).Count()
+	m.Set("a", 1)
+	r.AssertEqual(0, m.Count())
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
+
+func TestDoNothingUnusedStrictVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.StrictVerify())
+	m := Mock[Publisher](ctrl)
+	DoNothing[Publisher]().When(m).Close()
+	r.TriggerCleanup()
+	r.AssertError()
+}
+
+func TestStubDefinitionIsNotRecorded(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Publisher](ctrl)
+	DoNothing[Publisher]().When(m).Close()
+	Verify(m, Never()).Close()
+	VerifyNoMoreInteractions(m)
+	r.AssertNoError()
+}
+
+func TestDoNothingMockFunc(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	fn := MockFunc[Callback](ctrl)
+	DoPanic[Callback]("boom").When(fn)(Exact("a"))
+	r.AssertEqual("boom", catch(func() { fn("a") }))
+	r.AssertEqual(nil, catch(func() { fn("b") }))
+	r.AssertNoError()
+}
+
+func TestDoAnswerMatchersBeforeWhen(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Publisher](ctrl)
+	matcher := AnyString()
+	DoNothing[Publisher]().When(m).Set(matcher, AnyInt())
+	r.AssertError()
+}
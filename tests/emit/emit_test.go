@@ -0,0 +1,217 @@
+package emit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Event struct {
+	ID int
+}
+
+type Bus interface {
+	Subscribe(topic string) (<-chan Event, error)
+	Watch() (<-chan Event, <-chan error)
+	Publish() chan<- Event
+	Stream(ctx context.Context) <-chan Event
+	Count() int
+}
+
+func collect[T any](ch <-chan T) []T {
+	result := make([]T, 0)
+	for v := range ch {
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestEmitValues(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenDouble(m.Subscribe("orders")).ThenEmit(Event{ID: 1}, Event{ID: 2})
+	ch, err := m.Subscribe("orders")
+	r.AssertNoError()
+	r.AssertEqual(nil, err)
+	r.AssertEqual([]Event{{ID: 1}, {ID: 2}}, collect(ch))
+}
+
+func TestEmitFreshChannelPerCall(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenDouble(m.Subscribe(AnyString())).ThenEmit(Event{ID: 1})
+	first, _ := m.Subscribe("a")
+	second, _ := m.Subscribe("b")
+	r.AssertEqual([]Event{{ID: 1}}, collect(first))
+	r.AssertEqual([]Event{{ID: 1}}, collect(second))
+	r.AssertNoError()
+}
+
+func TestEmitNoValues(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenDouble(m.Subscribe(AnyString())).ThenEmit()
+	ch, _ := m.Subscribe("a")
+	r.AssertEqual(0, len(collect(ch)))
+	r.AssertNoError()
+}
+
+func TestEmitErrors(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	boom := errors.New("boom")
+	WhenDouble(m.Watch()).ThenEmit(Event{ID: 1}, boom, Event{ID: 2})
+	events, errs := m.Watch()
+	r.AssertEqual([]Event{{ID: 1}, {ID: 2}}, collect(events))
+	r.AssertEqual([]error{boom}, collect(errs))
+	r.AssertNoError()
+}
+
+func TestEmitMixedWithReturn(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	fail := errors.New("fail")
+	WhenDouble(m.Subscribe(AnyString())).
+		ThenReturn(nil, fail).
+		ThenEmit(Event{ID: 3})
+	_, err := m.Subscribe("a")
+	r.AssertEqual(fail, err)
+	ch, err := m.Subscribe("a")
+	r.AssertEqual(nil, err)
+	r.AssertEqual([]Event{{ID: 3}}, collect(ch))
+	r.AssertNoError()
+}
+
+func TestEmitInvalidValue(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenDouble(m.Subscribe(AnyString())).ThenEmit("not an event")
+	r.AssertErrorContains(r.GetError(), "invalid ThenEmit")
+	r.AssertErrorContains(r.GetError(), "can not be sent to channel of type <-chan emit.Event")
+}
+
+func TestEmitErrorWithoutErrorChannel(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenDouble(m.Subscribe(AnyString())).ThenEmit(errors.New("boom"))
+	r.AssertErrorContains(r.GetError(), "invalid ThenEmit")
+}
+
+func TestEmitSendOnlyChannel(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenSingle(m.Publish()).ThenEmit(Event{ID: 1})
+	r.AssertErrorContains(r.GetError(), "send-only channel")
+}
+
+func TestEmitWithoutChannel(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenSingle(m.Count()).ThenEmit(1)
+	r.AssertErrorContains(r.GetError(), "does not return a channel")
+}
+
+func events(n int) []any {
+	result := make([]any, n)
+	for i := range result {
+		result[i] = Event{ID: i}
+	}
+	return result
+}
+
+func TestEmitAsyncValues(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenDouble(m.Subscribe("orders")).ThenEmitAsync(Event{ID: 1}, Event{ID: 2})
+	ch, err := m.Subscribe("orders")
+	r.AssertNoError()
+	r.AssertEqual(nil, err)
+	r.AssertEqual(0, cap(ch))
+	r.AssertEqual([]Event{{ID: 1}, {ID: 2}}, collect(ch))
+}
+
+func TestEmitAsyncErrorsInOrder(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	boom := errors.New("boom")
+	WhenDouble(m.Watch()).ThenEmitAsync(Event{ID: 1}, boom, Event{ID: 2})
+	evs, errs := m.Watch()
+	received := make([]any, 0)
+	for evs != nil || errs != nil {
+		select {
+		case e, ok := <-evs:
+			if !ok {
+				evs = nil
+				continue
+			}
+			received = append(received, e)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			received = append(received, err)
+		}
+	}
+	r.AssertEqual([]any{Event{ID: 1}, boom, Event{ID: 2}}, received)
+	r.AssertNoError()
+}
+
+func TestEmitAsyncStopsWhenContextIsDone(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenSingle(m.Stream(AnyContext())).ThenEmitAsync(events(1000)...)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := m.Stream(ctx)
+	r.AssertEqual(Event{ID: 0}, <-ch)
+	cancel()
+	r.AssertEqual(true, len(collect(ch)) < 999)
+	r.AssertNoError()
+}
+
+func TestEmitAsyncStopsAtTestEnd(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenSingle(m.Stream(AnyContext())).ThenEmitAsync(events(1000)...)
+	ch := m.Stream(context.Background())
+	r.TriggerCleanup()
+	r.AssertEqual(true, len(collect(ch)) < 1000)
+	r.AssertNoError()
+}
+
+func TestEmitAsyncFreshChannelPerCall(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenDouble(m.Subscribe(AnyString())).ThenEmitAsync(Event{ID: 1})
+	first, _ := m.Subscribe("a")
+	second, _ := m.Subscribe("b")
+	r.AssertEqual([]Event{{ID: 1}}, collect(second))
+	r.AssertEqual([]Event{{ID: 1}}, collect(first))
+	r.AssertNoError()
+}
+
+func TestEmitAsyncInvalidValue(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Bus](ctrl)
+	WhenDouble(m.Subscribe(AnyString())).ThenEmitAsync("not an event")
+	r.AssertError()
+}
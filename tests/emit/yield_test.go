@@ -0,0 +1,92 @@
+//go:build go1.23
+
+package emit
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Store interface {
+	Keys() iter.Seq[string]
+	All() iter.Seq2[string, int]
+	Find(prefix string) (iter.Seq[string], error)
+}
+
+func TestYieldSeq(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Store](ctrl)
+	WhenSingle(m.Keys()).ThenYield("a", "b", "c")
+	result := make([]string, 0)
+	for k := range m.Keys() {
+		result = append(result, k)
+	}
+	r.AssertEqual([]string{"a", "b", "c"}, result)
+	r.AssertNoError()
+}
+
+func TestYieldSeq2(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Store](ctrl)
+	WhenSingle(m.All()).ThenYield("a", 1, "b", 2)
+	result := make(map[string]int)
+	for k, v := range m.All() {
+		result[k] = v
+	}
+	r.AssertEqual(map[string]int{"a": 1, "b": 2}, result)
+	r.AssertNoError()
+}
+
+func TestYieldEarlyBreak(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Store](ctrl)
+	WhenSingle(m.Keys()).ThenYield("a", "b", "c")
+	result := make([]string, 0)
+	for k := range m.Keys() {
+		result = append(result, k)
+		if k == "b" {
+			break
+		}
+	}
+	r.AssertEqual([]string{"a", "b"}, result)
+	r.AssertNoError()
+}
+
+func TestYieldWithError(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Store](ctrl)
+	WhenDouble(m.Find(AnyString())).ThenYield("x")
+	seq, err := m.Find("x")
+	r.AssertEqual(nil, err)
+	result := make([]string, 0)
+	for k := range seq {
+		result = append(result, k)
+	}
+	r.AssertEqual([]string{"x"}, result)
+	r.AssertNoError()
+}
+
+func TestYieldOddPairs(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Store](ctrl)
+	WhenSingle(m.All()).ThenYield("a", 1, "b")
+	r.AssertErrorContains(r.GetError(), "invalid ThenYield")
+	r.AssertErrorContains(r.GetError(), "yields pairs, got 3 values")
+}
+
+func TestYieldInvalidType(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Store](ctrl)
+	WhenSingle(m.Keys()).ThenYield(1)
+	r.AssertErrorContains(r.GetError(), "can not be yielded by iterator of type iter.Seq[string]")
+}
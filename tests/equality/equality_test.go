@@ -0,0 +1,117 @@
+package equality
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ovechkin-dm/mockio/v2/config"
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+// Decimal is stored as a string, so that "1.50" and "1.5" are different values of the same number.
+type Decimal struct {
+	value string
+}
+
+func (d Decimal) normalized() string {
+	return strings.TrimRight(strings.TrimRight(d.value, "0"), ".")
+}
+
+type Payment struct {
+	Amount Decimal
+	At     time.Time
+}
+
+type Ledger interface {
+	Credit(amount Decimal) bool
+	Pay(p Payment) bool
+	Batch(amounts []Decimal) bool
+}
+
+func decimalEquality() config.Option {
+	return mockopts.WithEquality(func(a, b Decimal) bool {
+		return a.normalized() == b.normalized()
+	})
+}
+
+func TestImplicitMatcher(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, decimalEquality())
+	m := Mock[Ledger](ctrl)
+	WhenSingle(m.Credit(Decimal{"1.50"})).ThenReturn(true)
+	r.AssertEqual(true, m.Credit(Decimal{"1.5"}))
+	r.AssertEqual(false, m.Credit(Decimal{"1.51"}))
+	r.AssertNoError()
+}
+
+func TestNestedValues(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, decimalEquality())
+	m := Mock[Ledger](ctrl)
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	WhenSingle(m.Pay(Payment{Amount: Decimal{"2.0"}, At: at})).ThenReturn(true)
+	r.AssertEqual(true, m.Pay(Payment{Amount: Decimal{"2"}, At: at}))
+	r.AssertNoError()
+}
+
+func TestEqualAndNotEqual(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, decimalEquality())
+	m := Mock[Ledger](ctrl)
+	WhenSingle(m.Credit(Equal(Decimal{"3.00"}))).ThenReturn(true)
+	r.AssertEqual(true, m.Credit(Decimal{"3"}))
+	r.AssertNoError()
+
+	n := Mock[Ledger](ctrl)
+	WhenSingle(n.Credit(NotEqual(Decimal{"3.00"}))).ThenReturn(true)
+	r.AssertEqual(false, n.Credit(Decimal{"3"}))
+	r.AssertEqual(true, n.Credit(Decimal{"4"}))
+	r.AssertNoError()
+}
+
+func TestOneOfAndSliceContains(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, decimalEquality())
+	m := Mock[Ledger](ctrl)
+	WhenSingle(m.Credit(OneOf(Decimal{"1.0"}, Decimal{"2.0"}))).ThenReturn(true)
+	WhenSingle(m.Batch(SliceContains(Decimal{"5.00"}))).ThenReturn(true)
+	r.AssertEqual(true, m.Credit(Decimal{"2"}))
+	r.AssertEqual(true, m.Batch([]Decimal{{"1"}, {"5"}}))
+	r.AssertEqual(false, m.Batch([]Decimal{{"1"}}))
+	r.AssertNoError()
+}
+
+func TestCombinedMatchers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, decimalEquality())
+	m := Mock[Ledger](ctrl)
+	WhenSingle(m.Credit(Not(Equal(Decimal{"1.0"})))).ThenReturn(true)
+	r.AssertEqual(false, m.Credit(Decimal{"1"}))
+	r.AssertEqual(true, m.Credit(Decimal{"2"}))
+	r.AssertNoError()
+}
+
+func TestVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, decimalEquality())
+	m := Mock[Ledger](ctrl)
+	m.Credit(Decimal{"7.10"})
+	Verify(m, Once()).Credit(Decimal{"7.1"})
+	r.AssertNoError()
+}
+
+func TestPerMockEquality(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	custom := Mock[Ledger](ctrl, decimalEquality())
+	plain := Mock[Ledger](ctrl)
+	WhenSingle(custom.Credit(Decimal{"1.0"})).ThenReturn(true)
+	WhenSingle(plain.Credit(Decimal{"1.0"})).ThenReturn(true)
+	r.AssertEqual(true, custom.Credit(Decimal{"1"}))
+	r.AssertEqual(false, plain.Credit(Decimal{"1"}))
+	r.AssertNoError()
+}
@@ -0,0 +1,145 @@
+package equalwith
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Item struct {
+	ID    string
+	Name  string
+	Price float64
+}
+
+type Order struct {
+	ID        string
+	Items     []Item
+	Labels    map[string]string
+	CreatedAt time.Time
+	cache     map[string]int
+}
+
+type Repo interface {
+	Save(o *Order) bool
+}
+
+func TestEqualWithIgnoreFields(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	expected := &Order{Items: []Item{{Name: "book"}}}
+	WhenSingle(m.Save(EqualWith(expected, mockopts.IgnoreFields("ID", "Items.ID")))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(&Order{ID: "1", Items: []Item{{ID: "2", Name: "book"}}}))
+	r.AssertEqual(false, m.Save(&Order{ID: "1", Items: []Item{{ID: "2", Name: "pen"}}}))
+	r.AssertNoError()
+}
+
+func TestEqualWithIgnoreUnexported(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	expected := &Order{ID: "1"}
+	WhenSingle(m.Save(EqualWith(expected, mockopts.IgnoreUnexported()))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(&Order{ID: "1", cache: map[string]int{"a": 1}}))
+	r.AssertNoError()
+}
+
+func TestEqualWithUnexportedCompared(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	expected := &Order{ID: "1", cache: map[string]int{"a": 1}}
+	WhenSingle(m.Save(EqualWith(expected))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(&Order{ID: "1", cache: map[string]int{"a": 1}}))
+	r.AssertEqual(false, m.Save(&Order{ID: "1", cache: map[string]int{"a": 2}}))
+	r.AssertNoError()
+}
+
+func TestEqualWithNilEqualsEmpty(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(EqualWith(&Order{}, mockopts.NilEqualsEmpty()))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(&Order{Items: []Item{}, Labels: map[string]string{}}))
+	r.AssertEqual(false, m.Save(&Order{Items: []Item{{}}}))
+	r.AssertNoError()
+}
+
+func TestEqualWithNilNotEqualsEmptyByDefault(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(EqualWith(&Order{}))).ThenReturn(true)
+	r.AssertEqual(false, m.Save(&Order{Items: []Item{}}))
+	r.AssertNoError()
+}
+
+func TestEqualWithFloatEpsilon(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	expected := &Order{Items: []Item{{Price: 0.3}}}
+	WhenSingle(m.Save(EqualWith(expected, mockopts.FloatEpsilon(1e-9)))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(&Order{Items: []Item{{Price: 0.1 + 0.2}}}))
+	r.AssertEqual(false, m.Save(&Order{Items: []Item{{Price: 0.31}}}))
+	r.AssertNoError()
+}
+
+func TestEqualWithTime(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	now := time.Now()
+	WhenSingle(m.Save(EqualWith(&Order{CreatedAt: now}))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(&Order{CreatedAt: now.Round(0)}))
+	r.AssertEqual(true, m.Save(&Order{CreatedAt: now.UTC()}))
+	r.AssertEqual(false, m.Save(&Order{CreatedAt: now.Add(time.Millisecond)}))
+	r.AssertNoError()
+}
+
+func TestEqualWithTimeTolerance(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	now := time.Now()
+	WhenSingle(m.Save(EqualWith(&Order{CreatedAt: now}, mockopts.TimeTolerance(time.Second)))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(&Order{CreatedAt: now.Add(-500 * time.Millisecond)}))
+	r.AssertEqual(false, m.Save(&Order{CreatedAt: now.Add(2 * time.Second)}))
+	r.AssertNoError()
+}
+
+func TestEqualWithComparer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	caseInsensitive := mockopts.Comparer(func(a, b Item) bool {
+		return strings.EqualFold(a.Name, b.Name)
+	})
+	WhenSingle(m.Save(EqualWith(&Order{Items: []Item{{Name: "Book"}}}, caseInsensitive))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(&Order{Items: []Item{{ID: "1", Name: "BOOK"}}}))
+	r.AssertEqual(false, m.Save(&Order{Items: []Item{{Name: "pen"}}}))
+	r.AssertNoError()
+}
+
+func TestEqualWithUnknownIgnoredField(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(EqualWith(&Order{}, mockopts.IgnoreFields("Items.SKU")))).ThenReturn(true)
+	r.AssertErrorContains(r.GetError(), "ignored field Items.SKU: type equalwith.Item has no field SKU")
+}
+
+func TestEqualWithMismatchReport(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	_ = m.Save(&Order{Items: []Item{{Name: "pen"}}})
+	Verify(m, Once()).Save(EqualWith(&Order{Items: []Item{{Name: "book"}}}))
+	r.AssertErrorContains(r.GetError(), "argument 0: Items[0].Name: expected book, got pen")
+}
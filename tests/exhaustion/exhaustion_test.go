@@ -0,0 +1,151 @@
+package exhaustion
+
+import (
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/config"
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type iface interface {
+	Foo(a int) int
+}
+
+func TestRepeatLastByDefault(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(1).ThenReturn(2)
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertEqual(2, m.Foo(1))
+	r.AssertEqual(2, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestCycle(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(1).ThenReturn(2).Cycle()
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertEqual(2, m.Foo(1))
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertEqual(2, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestCycleAll(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	When(m.Foo(1)).ThenReturn(1).ThenReturn(2).Cycle()
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertEqual(2, m.Foo(1))
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestFallbackToDefaults(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(1).FallbackToDefaults()
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertEqual(0, m.Foo(1))
+	r.AssertEqual(0, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestOnly(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(1).ThenReturn(2).Only()
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertEqual(2, m.Foo(1))
+	r.AssertNoError()
+	r.AssertEqual(0, m.Foo(1))
+	r.TriggerCleanup()
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "stub exhausted after 2 calls")
+}
+
+func TestOnlyRestubWithMatchers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(AnyInt())).ThenReturn(1).Only()
+	r.AssertEqual(1, m.Foo(1))
+	WhenSingle(m.Foo(AnyInt())).ThenReturn(2)
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
+
+func TestOnlyRestubWithPlainArgsFails(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(1).Only()
+	r.AssertEqual(1, m.Foo(1))
+	WhenSingle(m.Foo(1)).ThenReturn(2)
+	r.AssertError()
+	r.AssertEqual(true, r.ErrorContains("stub exhausted after 1 calls"))
+}
+
+func TestOnlyRestubWithEqual(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(1).Only()
+	r.AssertEqual(1, m.Foo(1))
+	WhenSingle(m.Foo(Equal(1))).ThenReturn(2)
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
+
+func TestOnlyWithoutAnswers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).Only()
+	r.AssertEqual(0, m.Foo(1))
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
+
+func TestGlobalPolicy(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.WhenExhausted(config.CycleAnswers))
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(1).ThenReturn(2)
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertEqual(2, m.Foo(1))
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestGlobalPolicyOverride(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.WhenExhausted(config.FailWhenExhausted))
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(1).RepeatLast()
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertEqual(1, m.Foo(1))
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
+
+func TestGlobalFailPolicy(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.WhenExhausted(config.FailWhenExhausted))
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(1)
+	m.Foo(1)
+	m.Foo(1)
+	Verify(m, Times(2)).Foo(1)
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "stub exhausted after 1 calls")
+}
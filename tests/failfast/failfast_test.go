@@ -0,0 +1,105 @@
+package failfast
+
+import (
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type storeImpl struct{}
+
+func (s *storeImpl) Get(key string, version int) string {
+	return "real"
+}
+
+func (s *storeImpl) Count() int {
+	return 10
+}
+
+func TestFailOnUnstubbedCall(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[Store](ctrl)
+	svc := &Service{store: m}
+	_ = svc.Count()
+	r.AssertError()
+	r.AssertEqual(1, r.GetFatalCount())
+	r.AssertEqual(true, r.ErrorContains("Store.Count()"))
+	r.AssertEqual(true, r.ErrorContains("no stubbings"))
+}
+
+func TestFailOnUnstubbedCallShowsStubbings(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[Store](ctrl)
+	WhenSingle(m.Get(Exact("a"), AnyInt())).ThenReturn("a")
+	WhenSingle(m.Get(Exact("b"), Exact(1))).ThenReturn("b")
+	svc := &Service{store: m}
+	r.AssertEqual("a", svc.Get("a", 1))
+	r.AssertNoError()
+	_ = svc.Get("c", 2)
+	r.AssertError()
+	r.AssertEqual(true, r.ErrorContains("Store.Get(c, 2)"))
+	r.AssertEqual(true, r.ErrorContains("Store.Get(Exact(a), Any[int])"))
+	r.AssertEqual(true, r.ErrorContains("Store.Get(Exact(b), Exact(1))"))
+	r.AssertEqual(true, r.ErrorContains("service.go"))
+	r.AssertEqual(true, r.ErrorContains("failfast_test.go"))
+}
+
+func TestFailOnUnstubbedCallWithoutArgs(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[Store](ctrl)
+	DoAnswer[Store](func(args []any) []any {
+		return []any{1}
+	}).When(m).Count()
+	svc := &Service{store: m}
+	r.AssertEqual(1, svc.Count())
+	r.AssertNoError()
+}
+
+func TestFailOnUnstubbedCallFromTestFile(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[Store](ctrl)
+	WhenSingle(m.Get(AnyString(), AnyInt())).ThenReturn("a")
+	_ = m.Count()
+	r.AssertError()
+	r.AssertEqual(1, r.GetFatalCount())
+	r.AssertEqual(true, r.ErrorContains("Store.Count()"))
+	r.TriggerCleanup()
+	r.AssertEqual(1, r.GetFatalCount())
+}
+
+func TestFailOnUnstubbedCallStubbingWithPlainArgs(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[Store](ctrl)
+	WhenSingle(m.Get("a", 1)).ThenReturn("a")
+	r.AssertError()
+	r.AssertEqual(true, r.ErrorContains("Store.Get(a, 1)"))
+}
+
+func TestFailOnUnstubbedCallVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[Store](ctrl)
+	DoAnswer[Store](func(args []any) []any {
+		return []any{1}
+	}).When(m).Count()
+	_ = m.Count()
+	Verify(m, Once()).Count()
+	Verify(m, Never()).Get("a", 1)
+	r.AssertNoError()
+}
+
+func TestFailOnUnstubbedCallSpy(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	s := Spy[Store](ctrl, &storeImpl{})
+	r.AssertEqual(10, s.Count())
+	r.AssertNoError()
+}
@@ -0,0 +1,19 @@
+package failfast
+
+type Store interface {
+	Get(key string, version int) string
+	Count() int
+}
+
+// Service is a code under test, that calls the mock outside of test files.
+type Service struct {
+	store Store
+}
+
+func (s *Service) Count() int {
+	return s.store.Count()
+}
+
+func (s *Service) Get(key string, version int) string {
+	return s.store.Get(key, version)
+}
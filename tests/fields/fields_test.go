@@ -0,0 +1,191 @@
+package fields
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Address struct {
+	City   string
+	Street string
+}
+
+type User struct {
+	ID      string
+	Name    string
+	Age     int64
+	Score   uint8
+	Address *Address
+	Tags    []string
+	secret  string
+}
+
+type Base struct {
+	ID int
+}
+
+type Admin struct {
+	*Base
+	Name string
+}
+
+type Repo interface {
+	Save(u User) bool
+	SavePtr(u *User) bool
+	SaveAdmin(a Admin) bool
+}
+
+func TestFieldsLiterals(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(Fields[User](F{"Name": "bob", "Age": 30}))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(User{ID: "generated", Name: "bob", Age: 30}))
+	r.AssertEqual(false, m.Save(User{ID: "generated", Name: "bob", Age: 31}))
+	r.AssertNoError()
+}
+
+func TestFieldsNestedPath(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.SavePtr(Fields[*User](F{"Address.City": "Paris"}))).ThenReturn(true)
+	r.AssertEqual(true, m.SavePtr(&User{Address: &Address{City: "Paris", Street: "Rivoli"}}))
+	r.AssertEqual(false, m.SavePtr(&User{Address: &Address{City: "Berlin"}}))
+	r.AssertEqual(false, m.SavePtr(&User{}))
+	r.AssertEqual(false, m.SavePtr(nil))
+	r.AssertNoError()
+}
+
+func TestFieldsWithMatchers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(Fields[User](F{
+		"Name": Is(Regex("^b")),
+		"Tags": Is(SliceContains("admin")),
+		"Age":  Is(Not(Exact[int64](0))),
+	}))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(User{Name: "bob", Age: 1, Tags: []string{"admin", "dev"}}))
+	r.AssertEqual(false, m.Save(User{Name: "bob", Age: 0, Tags: []string{"admin"}}))
+	r.AssertEqual(false, m.Save(User{Name: "alice", Age: 1, Tags: []string{"admin"}}))
+	r.AssertNoError()
+}
+
+func TestFieldsUnexported(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(Fields[User](F{"secret": "x"}))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(User{secret: "x"}))
+	r.AssertEqual(false, m.Save(User{secret: "y"}))
+	r.AssertNoError()
+}
+
+func TestFieldsWithOtherMatchers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(Fields[User](F{"Name": Is(Substring("o")), "Address": nil}))).ThenReturn(true)
+	Verify(m, Never()).Save(Fields[User](F{"Name": "bob"}))
+	r.AssertEqual(true, m.Save(User{Name: "tom"}))
+	r.AssertNoError()
+}
+
+func TestFieldsUnknownField(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(Fields[User](F{"Address.Zip": "123"}))).ThenReturn(true)
+	r.AssertErrorContains(r.GetError(), "Invalid matcher Fields[fields.User]")
+	r.AssertErrorContains(r.GetError(), "type fields.Address has no field Zip")
+}
+
+func TestFieldsWrongValueType(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(Fields[User](F{"Name": 1}))).ThenReturn(true)
+	r.AssertErrorContains(r.GetError(), "field Name: value of type int can not be compared with field of type string")
+}
+
+func TestFieldsIntegralFloat(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(Fields[User](F{"Age": 30.0, "Score": 255}))).ThenReturn(true)
+	r.AssertEqual(true, m.Save(User{Age: 30, Score: 255}))
+	r.AssertNoError()
+}
+
+func TestFieldsFractionalValueForIntField(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(Fields[User](F{"Age": 18.9}))).ThenReturn(true)
+	r.AssertErrorContains(r.GetError(), "field Age: value 18.9 of type float64 can not be represented by field of type int64")
+	r.AssertEqual(false, m.Save(User{Age: 18}))
+}
+
+func TestFieldsNegativeValueForUintField(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(Fields[User](F{"Score": -1}))).ThenReturn(true)
+	r.AssertErrorContains(r.GetError(), "field Score: value -1 of type int can not be represented by field of type uint8")
+}
+
+func TestFieldsOverflowingValue(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Save(Fields[User](F{"Score": 300}))).ThenReturn(true)
+	r.AssertErrorContains(r.GetError(), "field Score: value 300 of type int can not be represented by field of type uint8")
+}
+
+func TestFieldsMismatchReport(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.SavePtr(Fields[*User](F{"Name": "bob", "Address.City": "Paris"}))).ThenReturn(true)
+	_ = m.SavePtr(&User{Name: "bob", Address: &Address{City: "Berlin"}})
+	r.TriggerCleanup()
+	r.AssertErrorContains(r.GetError(), "field Address.City: expected Paris, got Berlin")
+}
+
+func TestFieldsVerifyMismatchReport(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	_ = m.Save(User{Name: "alice"})
+	Verify(m, Once()).Save(Fields[User](F{"Name": "bob"}))
+	r.AssertErrorContains(r.GetError(), "argument 0: field Name: expected bob, got alice")
+}
+
+func TestFieldsPromotedThroughNilPointer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.SaveAdmin(Fields[Admin](F{"ID": 1}))).ThenReturn(true)
+	r.AssertEqual(true, m.SaveAdmin(Admin{Base: &Base{ID: 1}}))
+	r.AssertEqual(false, m.SaveAdmin(Admin{Name: "root"}))
+	r.TriggerCleanup()
+	r.AssertErrorContains(r.GetError(), "field ID: Base is nil")
+}
+
+func TestFieldsWithEquality(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.WithEquality(strings.EqualFold))
+	m := Mock[Repo](ctrl)
+	_ = m.Save(User{Name: "bob"})
+	_ = m.Save(User{Name: "alice"})
+	Verify(m, Once()).Save(Fields[User](F{"Name": "BOB"}))
+	r.AssertNoError()
+	Verify(m, Once()).Save(Fields[User](F{"Name": "Bob", "Age": 1}))
+	r.AssertErrorContains(r.GetError(), "argument 0: field Age: expected 1, got 0")
+}
@@ -0,0 +1,169 @@
+package inject
+
+import (
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type UserRepo interface {
+	Get(id int) string
+}
+
+type OrderRepo interface {
+	Count(user string) int
+}
+
+type Logger interface {
+	Log(msg string)
+}
+
+type Service struct {
+	Users  UserRepo
+	Orders OrderRepo `mockio:"name=orders"`
+	Logger Logger    `mockio:"-"`
+	Limit  int
+	cache  UserRepo
+}
+
+func (s *Service) OrdersOf(id int) int {
+	return s.Orders.Count(s.Users.Get(id))
+}
+
+type noopLogger struct{}
+
+func (n *noopLogger) Log(msg string) {}
+
+func TestInjectMocks(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	svc := &Service{}
+	mocks := InjectMocks(ctrl, svc)
+	WhenSingle(Injected[UserRepo](mocks, "Users").Get(1)).ThenReturn("john")
+	WhenSingle(Injected[OrderRepo](mocks, "orders").Count("john")).ThenReturn(3)
+	r.AssertEqual(3, svc.OrdersOf(1))
+	Verify(Injected[OrderRepo](mocks, "orders"), Once()).Count("john")
+	r.AssertEqual(nil, svc.Logger)
+	r.AssertEqual(nil, svc.cache)
+	r.AssertEqual([]string{"Users", "orders"}, mocks.Names())
+	r.AssertNoError()
+}
+
+func TestInjectMocksKeepsNonNilFields(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	users := Mock[UserRepo](ctrl)
+	svc := &Service{Users: users}
+	mocks := InjectMocks(ctrl, svc)
+	r.AssertEqual(users, svc.Users)
+	_, ok := mocks.Get("Users")
+	r.AssertEqual(false, ok)
+	r.AssertNoError()
+}
+
+func TestInjectMocksUnexported(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	svc := &Service{Logger: &noopLogger{}}
+	mocks := InjectMocks(ctrl, svc, mockopts.InjectUnexported())
+	WhenSingle(Injected[UserRepo](mocks, "cache").Get(1)).ThenReturn("cached")
+	r.AssertEqual("cached", svc.cache.Get(1))
+	r.AssertNoError()
+}
+
+func TestInjectMocksSkipsErrorsAndEmptyInterfaces(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	svc := &struct {
+		Users UserRepo
+		Err   error
+		Value any
+	}{}
+	mocks := InjectMocks(ctrl, svc)
+	r.AssertEqual(nil, svc.Err)
+	r.AssertEqual(nil, svc.Value)
+	r.AssertEqual([]string{"Users"}, mocks.Names())
+	r.AssertNoError()
+}
+
+func TestInjectMocksUnexportedWithMockOptions(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	svc := &Service{}
+	_ = InjectMocks(ctrl, svc, mockopts.InjectUnexported(), mockopts.StrictVerify())
+	svc.cache.Get(1)
+	r.TriggerCleanup()
+	r.AssertError()
+}
+
+func TestInjectMocksName(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	svc := &Service{}
+	mocks := InjectMocks(ctrl, svc)
+	svc.Orders.Count("john")
+	Verify(Injected[OrderRepo](mocks, "orders"), Never()).Count("john")
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "orders.Count")
+}
+
+func TestInjectMocksStrictVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	svc := &Service{}
+	_ = InjectMocks(ctrl, svc, mockopts.StrictVerify())
+	svc.Users.Get(1)
+	r.TriggerCleanup()
+	r.AssertError()
+}
+
+func TestInjectedUnknownName(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	mocks := InjectMocks(ctrl, &Service{})
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, but code did not panic")
+		}
+	}()
+	_ = Injected[UserRepo](mocks, "Unknown")
+}
+
+func TestInjectedWrongType(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	mocks := InjectMocks(ctrl, &Service{})
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, but code did not panic")
+		}
+	}()
+	_ = Injected[OrderRepo](mocks, "Users")
+}
+
+func TestInjectMocksNotAPointer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, but code did not panic")
+		}
+	}()
+	_ = InjectMocks(ctrl, Service{})
+}
+
+func TestInjectMocksInvalidTag(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, but code did not panic")
+		}
+	}()
+	_ = InjectMocks(ctrl, &struct {
+		Users UserRepo `mockio:"alias=users"`
+	}{})
+}
@@ -0,0 +1,129 @@
+package invocation
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/matchers"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type iface interface {
+	Fetch(page int) (string, error)
+	Sum(values ...int) int
+	Name() string
+}
+
+var errBusy = errors.New("busy")
+
+func TestStubCallIndex(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	When(m.Fetch(AnyInt())).ThenAnswerInvocation(func(inv matchers.Invocation) []any {
+		if inv.StubCallIndex < 2 {
+			return []any{"", errBusy}
+		}
+		return []any{"page", nil}
+	})
+	for i := 0; i < 2; i++ {
+		_, err := m.Fetch(1)
+		r.AssertEqual(errBusy, err)
+	}
+	v, err := m.Fetch(1)
+	r.AssertEqual("page", v)
+	r.AssertEqual(nil, err)
+	r.AssertNoError()
+}
+
+func TestMethodCallIndex(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	_, _ = m.Fetch(1)
+	WhenDouble(m.Fetch(2)).ThenAnswerInvocation(func(inv matchers.Invocation) (string, error) {
+		return "", nil
+	})
+	indexes := make([]int, 0)
+	WhenDouble(m.Fetch(3)).ThenAnswerInvocation(func(inv matchers.Invocation) (string, error) {
+		indexes = append(indexes, inv.MethodCallIndex, inv.StubCallIndex)
+		return "", nil
+	})
+	_, _ = m.Fetch(2)
+	_, _ = m.Fetch(3)
+	r.AssertEqual([]int{2, 0}, indexes)
+	r.AssertNoError()
+}
+
+func TestInvocationDescription(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	var captured matchers.Invocation
+	WhenSingle(m.Sum(AnyInt(), AnyInt())).ThenAnswerInvocation(func(inv matchers.Invocation) int {
+		captured = inv
+		return inv.Args[0].(int) + inv.Args[1].(int)
+	})
+	r.AssertEqual(3, m.Sum(1, 2))
+	r.AssertEqual("Sum", captured.MethodName)
+	r.AssertEqual("Sum", captured.MethodType.Name)
+	r.AssertEqual([]any{1, 2}, captured.Args)
+	r.AssertEqual(m, captured.Mock)
+	r.AssertEqual(true, captured.GoroutineID != 0)
+	r.AssertEqual(true, strings.Contains(captured.StubbedAt, "invocation_test.go"))
+	r.AssertNoError()
+}
+
+func TestInvocationGoroutineID(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	lock := sync.Mutex{}
+	ids := make(map[int64]struct{})
+	WhenSingle(m.Name()).ThenAnswerInvocation(func(inv matchers.Invocation) string {
+		lock.Lock()
+		defer lock.Unlock()
+		ids[inv.GoroutineID] = struct{}{}
+		return ""
+	})
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Name()
+		}()
+	}
+	wg.Wait()
+	r.AssertEqual(5, len(ids))
+	r.AssertNoError()
+}
+
+func TestInvocationAnswerSequence(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Name()).
+		ThenReturn("first").
+		ThenAnswerInvocation(func(inv matchers.Invocation) string {
+			return inv.MethodName
+		})
+	r.AssertEqual("first", m.Name())
+	r.AssertEqual("Name", m.Name())
+	r.AssertNoError()
+}
+
+func TestInvocationInvalidReturnValues(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	When(m.Name()).ThenAnswerInvocation(func(inv matchers.Invocation) []any {
+		return []any{1}
+	})
+	m.Name()
+	r.AssertError()
+}
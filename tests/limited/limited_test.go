@@ -0,0 +1,130 @@
+package limited
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type iface interface {
+	Get(id string) (string, error)
+	Foo(a int) int
+}
+
+var errBusy = errors.New("busy")
+
+func TestTimesFallsThrough(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenDouble(m.Get(AnyString())).ThenReturn("", errBusy).Times(2)
+	WhenDouble(m.Get(AnyString())).ThenReturn("john", nil)
+	for i := 0; i < 2; i++ {
+		_, err := m.Get("a")
+		r.AssertEqual(errBusy, err)
+	}
+	v, err := m.Get("a")
+	r.AssertEqual("john", v)
+	r.AssertEqual(nil, err)
+	r.AssertNoError()
+}
+
+func TestTimesToDefaults(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(10).Times(1)
+	r.AssertEqual(10, m.Foo(1))
+	r.AssertEqual(0, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestTimesWithConsecutiveAnswers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	When(m.Foo(AnyInt())).ThenReturn(1).ThenReturn(2).Times(3)
+	When(m.Foo(AnyInt())).ThenReturn(100)
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertEqual(2, m.Foo(1))
+	r.AssertEqual(2, m.Foo(1))
+	r.AssertEqual(100, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestTimesLayered(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(AnyInt())).ThenReturn(1).Times(1)
+	WhenSingle(m.Foo(AnyInt())).ThenReturn(2).Times(1)
+	WhenSingle(m.Foo(AnyInt())).ThenReturn(3)
+	r.AssertEqual(1, m.Foo(1))
+	r.AssertEqual(2, m.Foo(1))
+	r.AssertEqual(3, m.Foo(1))
+	r.AssertEqual(3, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestTimesSpecificOverGeneral(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(10).Times(1)
+	WhenSingle(m.Foo(AnyInt())).ThenReturn(20)
+	r.AssertEqual(20, m.Foo(2))
+	r.AssertEqual(10, m.Foo(1))
+	r.AssertEqual(20, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestTimesConcurrent(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(AnyInt())).ThenReturn(1).Times(50)
+	WhenSingle(m.Foo(AnyInt())).ThenReturn(2)
+	results := make(chan int, 100)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- m.Foo(1)
+		}()
+	}
+	wg.Wait()
+	close(results)
+	sum := 0
+	for v := range results {
+		sum += v
+	}
+	r.AssertEqual(150, sum)
+	r.AssertNoError()
+}
+
+func TestTimesFailOnUnstubbedCall(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(Exact(1))).ThenReturn(10).Times(1)
+	m.Foo(1)
+	m.Foo(1)
+	r.TriggerCleanup()
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "Unstubbed call")
+}
+
+func TestTimesInvalid(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(10).Times(0)
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "invalid stubbing limit")
+}
@@ -0,0 +1,110 @@
+package mockfunc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Fetcher func(ctx context.Context, id string) error
+
+func TestMockFuncReturn(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	fn := MockFunc[Fetcher](ctrl)
+	expected := errors.New("not found")
+	WhenSingle(fn(AnyContext(), Exact("a"))).ThenReturn(expected)
+	r.AssertEqual(expected, fn(context.Background(), "a"))
+	r.AssertEqual(nil, fn(context.Background(), "b"))
+	r.AssertNoError()
+}
+
+func TestMockFuncUnnamed(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	fn := MockFunc[func(a int, b int) int](ctrl)
+	WhenSingle(fn(AnyInt(), AnyInt())).ThenAnswer(func(args []any) int {
+		return args[0].(int) + args[1].(int)
+	})
+	r.AssertEqual(3, fn(1, 2))
+	r.AssertNoError()
+}
+
+func TestMockFuncVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	fn := MockFunc[Fetcher](ctrl)
+	_ = fn(context.Background(), "a")
+	_ = fn(context.Background(), "b")
+	Verify(fn, Once())(AnyContext(), Exact("a"))
+	Verify(fn, Times(2))(AnyContext(), AnyString())
+	VerifyNoMoreInteractions(fn)
+	r.AssertNoError()
+}
+
+func TestMockFuncVerifyFails(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	fn := MockFunc[Fetcher](ctrl)
+	_ = fn(context.Background(), "a")
+	Verify(fn, Never())(AnyContext(), Exact("a"))
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "Fetcher(")
+}
+
+func TestMockFuncCaptor(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	fn := MockFunc[Fetcher](ctrl)
+	c := Captor[string]()
+	WhenSingle(fn(AnyContext(), c.Capture())).ThenReturn(nil)
+	_ = fn(context.Background(), "a")
+	r.AssertEqual("a", c.Last())
+	r.AssertNoError()
+}
+
+func TestMockFuncVariadic(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	fn := MockFunc[func(prefix string, values ...int) int](ctrl)
+	WhenSingle(fn("a", 1, 2)).ThenReturn(3)
+	r.AssertEqual(3, fn("a", 1, 2))
+	r.AssertEqual(0, fn("a", 1))
+	Verify(fn, Once())(AnyString(), AnyInt())
+	r.AssertNoError()
+}
+
+func TestMockFuncStrictVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.StrictVerify())
+	fn := MockFunc[Fetcher](ctrl)
+	WhenSingle(fn(AnyContext(), Exact("a"))).ThenReturn(nil)
+	r.TriggerCleanup()
+	r.AssertError()
+}
+
+func TestMockFuncNotAFunc(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, but code did not panic")
+		}
+	}()
+	_ = MockFunc[int](ctrl)
+}
+
+func TestVerifyNotAMockFunc(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, but code did not panic")
+		}
+	}()
+	fn := func(a int) int { return a }
+	Verify(fn, Once())
+}
@@ -0,0 +1,126 @@
+package named
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/registry"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Store interface {
+	Get(key string) string
+	Keys() []string
+}
+
+type Client interface {
+	Store() Store
+}
+
+func TestNameInVerifyReport(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	primary := Mock[Store](ctrl, mockopts.Name("primary"))
+	replica := Mock[Store](ctrl, mockopts.Name("replica"))
+	_ = primary.Get("a")
+	Verify(primary, Once()).Get("a")
+	r.AssertNoError()
+	Verify(replica, Once()).Get("a")
+	r.AssertError()
+	r.AssertEqual(true, r.ErrorContains("replica.Get(Equal(a))"))
+}
+
+func TestNameInNoMoreInteractionsReport(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	replica := Mock[Store](ctrl, mockopts.Name("replica"))
+	_ = replica.Get("a")
+	VerifyNoMoreInteractions(replica)
+	r.AssertError()
+	r.AssertEqual(true, r.ErrorContains("replica.Get(a)"))
+}
+
+func TestNameInReturnValuesReport(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	replica := Mock[Store](ctrl, mockopts.Name("replica"))
+	When(replica.Get("a")).ThenReturn(10)
+	_ = replica.Get("a")
+	r.AssertError()
+	r.AssertEqual(true, r.ErrorContains("replica.Get(string) string"))
+}
+
+func TestNameRejectedForController(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.Name("replica"))
+	r.AssertError()
+	r.AssertEqual(true, r.ErrorContains("mockopts.Name can not be passed to NewMockController"))
+	m := Mock[Store](ctrl)
+	Verify(m, Once()).Get("a")
+	r.AssertEqual(true, r.ErrorContains("Store.Get(Equal(a))"))
+}
+
+func TestPrettyPrintMethodInvocation(t *testing.T) {
+	r := common.NewMockReporter(t)
+	tp := reflect.TypeOf((*Store)(nil)).Elem()
+	method, _ := tp.MethodByName("Get")
+	r.AssertEqual("Store.Get(a)", registry.PrettyPrintMethodInvocation(tp, method, []string{"a"}))
+}
+
+func TestUnnamedMock(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Store](ctrl)
+	Verify(m, Once()).Get("a")
+	r.AssertError()
+	r.AssertEqual(true, r.ErrorContains("Store.Get(Equal(a))"))
+}
+
+func TestNamedFunc(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	fn := MockFunc[func(a int) int](ctrl, mockopts.Name("callback"))
+	Verify(fn, Once())(1)
+	r.AssertError()
+	r.AssertEqual(true, r.ErrorContains("callback(Equal(1))"))
+}
+
+func TestNamedDeepStub(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	c := Mock[Client](ctrl, mockopts.Name("client"), mockopts.DeepStubs())
+	Verify(c.Store(), Once()).Get("a")
+	r.AssertError()
+	r.AssertEqual(true, r.ErrorContains("client.Store().Get(Equal(a))"))
+}
+
+func TestPerMockStrictVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	strict := Mock[Store](ctrl, mockopts.StrictVerify())
+	_ = strict.Get("a")
+	r.TriggerCleanup()
+	r.AssertError()
+}
+
+func TestPerMockWithoutStrictVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.StrictVerify())
+	lenient := Mock[Store](ctrl, mockopts.WithoutStrictVerify())
+	_ = lenient.Get("a")
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
+
+func TestPerMockDefaultAnswer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Store](ctrl, mockopts.ReturnEmptyValues())
+	other := Mock[Store](ctrl)
+	r.AssertEqual([]string{}, m.Keys())
+	r.AssertEqual(true, other.Keys() == nil)
+	r.AssertNoError()
+}
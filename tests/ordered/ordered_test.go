@@ -0,0 +1,128 @@
+package ordered
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Account interface {
+	Withdraw(amount int) bool
+	Find(name string) bool
+	Convert(rate float64) bool
+	Schedule(at time.Time) bool
+	SetTimeout(d time.Duration) bool
+}
+
+func TestGtLt(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Account](ctrl)
+	WhenSingle(m.Withdraw(Gt(100))).ThenReturn(false)
+	WhenSingle(m.Withdraw(Lt(0))).ThenReturn(false)
+	WhenSingle(m.Withdraw(AnyInt())).ThenReturn(true)
+	r.AssertEqual(false, m.Withdraw(101))
+	r.AssertEqual(true, m.Withdraw(100))
+	r.AssertEqual(true, m.Withdraw(0))
+	r.AssertEqual(false, m.Withdraw(-1))
+	r.AssertNoError()
+}
+
+func TestGteLte(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Account](ctrl)
+	WhenSingle(m.Withdraw(And(Gte(10), Lte(20)))).ThenReturn(true)
+	r.AssertEqual(true, m.Withdraw(10))
+	r.AssertEqual(true, m.Withdraw(20))
+	r.AssertEqual(false, m.Withdraw(9))
+	r.AssertEqual(false, m.Withdraw(21))
+	r.AssertNoError()
+}
+
+func TestBetweenStrings(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Account](ctrl)
+	WhenSingle(m.Find(Between("a", "m"))).ThenReturn(true)
+	r.AssertEqual(true, m.Find("alice"))
+	r.AssertEqual(true, m.Find("m"))
+	r.AssertEqual(false, m.Find("nick"))
+	r.AssertNoError()
+}
+
+func TestNaNNeverMatches(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Account](ctrl)
+	WhenSingle(m.Convert(Lt(0.0))).ThenReturn(true)
+	WhenSingle(m.Convert(Gte(0.0))).ThenReturn(true)
+	WhenSingle(m.Convert(Between(-1.0, 1.0))).ThenReturn(true)
+	r.AssertEqual(false, m.Convert(math.NaN()))
+	r.AssertEqual(true, m.Convert(-1))
+	r.AssertNoError()
+}
+
+func TestApprox(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Account](ctrl)
+	WhenSingle(m.Convert(Approx(0.3, 1e-9))).ThenReturn(true)
+	r.AssertEqual(true, m.Convert(0.1+0.2))
+	r.AssertEqual(false, m.Convert(0.31))
+	r.AssertNoError()
+}
+
+func TestAfterBefore(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Account](ctrl)
+	now := time.Now()
+	WhenSingle(m.Schedule(After(now))).ThenReturn(true)
+	WhenSingle(m.Schedule(Before(now))).ThenReturn(false)
+	r.AssertEqual(true, m.Schedule(now.Add(time.Hour)))
+	r.AssertEqual(false, m.Schedule(now.Add(-time.Hour)))
+	r.AssertNoError()
+}
+
+func TestWithinDuration(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Account](ctrl)
+	now := time.Now()
+	WhenSingle(m.Schedule(WithinDuration(now, time.Second))).ThenReturn(true)
+	r.AssertEqual(true, m.Schedule(now.Add(-time.Second)))
+	r.AssertEqual(true, m.Schedule(now.Add(500*time.Millisecond)))
+	r.AssertEqual(false, m.Schedule(now.Add(2*time.Second)))
+	r.AssertNoError()
+}
+
+func TestDurationBetween(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Account](ctrl)
+	WhenSingle(m.SetTimeout(DurationBetween(time.Second, time.Minute))).ThenReturn(true)
+	r.AssertEqual(true, m.SetTimeout(time.Second))
+	r.AssertEqual(true, m.SetTimeout(time.Minute))
+	r.AssertEqual(false, m.SetTimeout(time.Millisecond))
+	r.AssertNoError()
+}
+
+func TestDescriptions(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Account](ctrl)
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	Verify(m, Once()).Withdraw(Between(1, 10))
+	r.AssertErrorContains(r.GetError(), "Withdraw(Between(1, 10))")
+	Verify(m, Once()).Convert(Approx(0.5, 0.01))
+	r.AssertErrorContains(r.GetError(), "Convert(Approx(0.5, ±0.01))")
+	Verify(m, Once()).Schedule(WithinDuration(at, time.Second))
+	r.AssertErrorContains(r.GetError(), "Schedule(WithinDuration(2024-01-02 03:04:05 +0000 UTC, 1s))")
+	Verify(m, Once()).SetTimeout(DurationBetween(time.Second, time.Minute))
+	r.AssertErrorContains(r.GetError(), "SetTimeout(DurationBetween(1s, 1m0s))")
+}
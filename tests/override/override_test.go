@@ -0,0 +1,201 @@
+package override
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type iface interface {
+	Get(id string) string
+	Put(v any) string
+}
+
+type key struct {
+	name string
+	tags []string
+}
+
+func setup(m iface) {
+	WhenSingle(m.Get("a")).ThenReturn("setup")
+	WhenSingle(m.Get(AnyString())).ThenReturn("any")
+}
+
+func TestFirstStubbingWinsByDefault(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	setup(m)
+	WhenSingle(m.Get("a")).ThenReturn("test")
+	r.AssertEqual("setup", m.Get("a"))
+	r.AssertNoError()
+}
+
+func TestOverride(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	setup(m)
+	WhenSingle(m.Get("a")).ThenReturn("test").Override()
+	r.AssertEqual("test", m.Get("a"))
+	r.AssertEqual("any", m.Get("b"))
+	r.AssertNoError()
+}
+
+func TestOverrideWithMatchers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Get(AnyString())).ThenReturn("setup")
+	WhenSingle(m.Get(AnyString())).ThenReturn("test").Override()
+	r.AssertEqual("test", m.Get("a"))
+	r.AssertNoError()
+}
+
+func TestOverrideStrictVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.StrictVerify())
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Get("a")).ThenReturn("setup")
+	WhenSingle(m.Get("a")).ThenReturn("test").Override()
+	r.AssertEqual("test", m.Get("a"))
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
+
+func TestLastStubbingWins(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.LastStubbingWins())
+	m := Mock[iface](ctrl)
+	setup(m)
+	r.AssertEqual("any", m.Get("a"))
+	WhenSingle(m.Get("a")).ThenReturn("test")
+	r.AssertEqual("test", m.Get("a"))
+	r.AssertEqual("any", m.Get("b"))
+	r.AssertNoError()
+}
+
+func TestLastStubbingWinsStrictVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.LastStubbingWins(), mockopts.StrictVerify())
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Get("a")).ThenReturn("setup")
+	WhenSingle(m.Get("a")).ThenReturn("test")
+	r.AssertEqual("test", m.Get("a"))
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
+
+func TestLastStubbingWinsKeepsAnswerOrder(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.LastStubbingWins())
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Get(AnyString())).ThenReturn("first").ThenReturn("second")
+	WhenSingle(m.Get("a")).ThenReturn("a")
+	r.AssertEqual("first", m.Get("b"))
+	r.AssertEqual("second", m.Get("b"))
+	r.AssertEqual("a", m.Get("a"))
+	r.AssertNoError()
+}
+
+func TestOverrideWithoutExistingStubbing(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Get("a")).ThenReturn("test").Override()
+	r.AssertEqual("test", m.Get("a"))
+	r.AssertNoError()
+}
+
+func TestOverrideKeepsCustomMatchersWithSameDescription(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	short := CreateMatcher[string]("custom", func(args []any, s string) bool {
+		return len(s) < 3
+	})
+	long := CreateMatcher[string]("custom", func(args []any, s string) bool {
+		return len(s) >= 3
+	})
+	WhenSingle(m.Get(short())).ThenReturn("short")
+	WhenSingle(m.Get(long())).ThenReturn("long").Override()
+	r.AssertEqual("short", m.Get("a"))
+	r.AssertEqual("long", m.Get("abc"))
+	WhenSingle(m.Get(short())).ThenReturn("again").Override()
+	r.AssertEqual("again", m.Get("a"))
+	r.AssertNoError()
+}
+
+func TestOverrideKeepsEqualWithMatchersWithOtherOptions(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Get(EqualWith("A", mockopts.Comparer(strings.EqualFold)))).ThenReturn("fold")
+	WhenSingle(m.Get(EqualWith("A"))).ThenReturn("exact").Override()
+	r.AssertEqual("fold", m.Get("a"))
+	r.AssertNoError()
+}
+
+func TestOverrideWithMatchersDoesNotRunAnswers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	calls := 0
+	WhenSingle(m.Get("a")).ThenDo(func(id string) string {
+		calls++
+		return "do"
+	})
+	WhenSingle(m.Get(Equal("a"))).ThenReturn("test").Override()
+	WhenSingle(m.Get("b")).ThenPanic("boom")
+	WhenSingle(m.Get(Equal("b"))).ThenReturn("b").Override()
+	r.AssertEqual(0, calls)
+	r.AssertEqual("test", m.Get("a"))
+	r.AssertEqual("b", m.Get("b"))
+	r.AssertNoError()
+}
+
+func TestOverrideEqualWithPlainArgs(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Get(Equal("a"))).ThenReturn("setup")
+	WhenSingle(m.Get("a")).ThenReturn("test").Override()
+	r.AssertEqual("test", m.Get("a"))
+	r.AssertNoError()
+}
+
+func TestLastStubbingWinsKeepsValuesPrintedTheSame(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.LastStubbingWins())
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Put(1)).ThenReturn("int")
+	WhenSingle(m.Put("1")).ThenReturn("string")
+	r.AssertEqual("int", m.Put(1))
+	r.AssertEqual("string", m.Put("1"))
+	r.AssertNoError()
+}
+
+func TestOverrideKeepsPointersPrintedTheSame(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Put(&key{name: "a"})).ThenReturn("nil tags")
+	WhenSingle(m.Put(&key{name: "a", tags: []string{}})).ThenReturn("empty tags").Override()
+	r.AssertEqual("nil tags", m.Put(&key{name: "a"}))
+	r.AssertEqual("empty tags", m.Put(&key{name: "a", tags: []string{}}))
+	r.AssertNoError()
+}
+
+func TestOverrideEqualPointers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Put(&key{name: "a"})).ThenReturn("setup")
+	WhenSingle(m.Put(Equal[any](&key{name: "a"}))).ThenReturn("test").Override()
+	r.AssertEqual("test", m.Put(&key{name: "a"}))
+	r.AssertNoError()
+}
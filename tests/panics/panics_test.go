@@ -0,0 +1,134 @@
+package panics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type iface interface {
+	Foo(a int) int
+	Bar(a int) (string, error)
+	Baz(a int, b string) (int, string, error)
+}
+
+func catch(f func()) (result any) {
+	defer func() {
+		result = recover()
+	}()
+	f()
+	return nil
+}
+
+func TestThenPanicSingle(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenPanic("boom")
+	r.AssertEqual("boom", catch(func() { m.Foo(1) }))
+	r.AssertEqual(nil, catch(func() { m.Foo(2) }))
+	r.AssertNoError()
+}
+
+func TestThenPanicExactValue(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	err := errors.New("boom")
+	WhenDouble(m.Bar(AnyInt())).ThenPanic(err)
+	recovered := catch(func() { _, _ = m.Bar(1) })
+	r.AssertEqual(true, recovered == err)
+	r.AssertNoError()
+}
+
+func TestThenPanicAll(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	When(m.Baz(AnyInt(), AnyString())).ThenPanic("boom")
+	r.AssertEqual("boom", catch(func() { _, _, _ = m.Baz(1, "a") }))
+	r.AssertNoError()
+}
+
+func TestThenPanicSequence(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).
+		ThenReturn(10).
+		ThenPanic("boom").
+		ThenReturn(20)
+	r.AssertEqual(10, m.Foo(1))
+	r.AssertEqual("boom", catch(func() { m.Foo(1) }))
+	r.AssertEqual(20, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestThenPanicIsRecorded(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(AnyInt())).ThenPanic("boom")
+	_ = catch(func() { m.Foo(1) })
+	_ = catch(func() { m.Foo(2) })
+	Verify(m, Times(2)).Foo(AnyInt())
+	VerifyNoMoreInteractions(m)
+	r.AssertNoError()
+}
+
+func TestThenPanicRestubWithMatchers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(AnyInt())).ThenPanic("boom")
+	WhenSingle(m.Foo(Exact(1))).ThenReturn(10)
+	r.AssertEqual("boom", catch(func() { m.Foo(1) }))
+	r.AssertNoError()
+}
+
+func TestThenPanicRestubWithPlainArgsPanics(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenPanic("boom")
+	r.AssertEqual("boom", catch(func() {
+		WhenSingle(m.Foo(1)).ThenReturn(10)
+	}))
+}
+
+func TestThenPanicOverrideWithMatchers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenDouble(m.Bar(1)).ThenPanic("boom")
+	WhenDouble(m.Bar(Equal(1))).ThenReturn("ok", nil).Override()
+	s, err := m.Bar(1)
+	r.AssertEqual("ok", s)
+	r.AssertNoError()
+	r.AssertEqual(nil, err)
+}
+
+func TestThenPanicStrictVerifyReport(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.StrictVerify())
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenPanic("boom")
+	r.TriggerCleanup()
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "panics with: boom")
+}
+
+func TestThenPanicUnstubbedCallReport(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.FailOnUnstubbedCall())
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(Exact(1))).ThenPanic("boom")
+	m.Foo(2)
+	r.TriggerCleanup()
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "panics with: boom")
+}
@@ -0,0 +1,102 @@
+package reset
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type iface interface {
+	Foo(a int) int
+	Bar() string
+}
+
+func TestReset(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(10)
+	r.AssertEqual(10, m.Foo(1))
+	Reset(m)
+	r.AssertEqual(0, m.Foo(1))
+	Verify(m, Once()).Foo(1)
+	VerifyNoMoreInteractions(m)
+	r.AssertNoError()
+}
+
+func TestResetAllowsNewStubbing(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(10)
+	Reset(m)
+	WhenSingle(m.Foo(1)).ThenReturn(20)
+	r.AssertEqual(20, m.Foo(1))
+	r.AssertNoError()
+}
+
+func TestClearInvocations(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(10)
+	r.AssertEqual(10, m.Foo(1))
+	ClearInvocations(m)
+	Verify(m, Never()).Foo(1)
+	r.AssertEqual(10, m.Foo(1))
+	Verify(m, Once()).Foo(1)
+	r.AssertNoError()
+}
+
+func TestResetStrictVerifyTearDown(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.StrictVerify())
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(10)
+	_ = m.Bar()
+	Reset(m)
+	WhenSingle(m.Foo(2)).ThenReturn(20)
+	_ = m.Foo(2)
+	r.TriggerCleanup()
+	r.AssertNoError()
+}
+
+func TestClearInvocationsStrictVerifyTearDown(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.StrictVerify())
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(1)).ThenReturn(10)
+	_ = m.Foo(1)
+	ClearInvocations(m)
+	r.TriggerCleanup()
+	r.AssertError()
+}
+
+func TestResetConcurrent(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Foo(AnyInt())).ThenReturn(10)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = m.Foo(j)
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		ClearInvocations(m)
+	}
+	Reset(m)
+	wg.Wait()
+	Reset(m)
+	Verify(m, Never()).Foo(AnyInt())
+	r.AssertNoError()
+}
@@ -0,0 +1,166 @@
+package setarg
+
+import (
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type User struct {
+	Name string
+}
+
+type iface interface {
+	Decode(dst any) error
+	Load(id int, dst *User) error
+	Scan(dest ...any) error
+	Read(p []byte) (int, error)
+	Count(id int) int
+}
+
+func TestSetArgPointer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Load(AnyInt(), Any[*User]())).
+		ThenSetArg(1, User{Name: "John"}).
+		ThenReturn(nil)
+	u := &User{}
+	err := m.Load(1, u)
+	r.AssertEqual(nil, err)
+	r.AssertEqual("John", u.Name)
+	r.AssertNoError()
+}
+
+func TestSetArgInterface(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Decode(Any[any]())).ThenSetArg(0, User{Name: "John"})
+	u := User{}
+	err := m.Decode(&u)
+	r.AssertEqual(nil, err)
+	r.AssertEqual("John", u.Name)
+	r.AssertNoError()
+}
+
+func TestSetArgSequence(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Load(AnyInt(), Any[*User]())).
+		ThenSetArg(1, User{Name: "John"}).
+		ThenReturn(nil).
+		ThenSetArg(1, User{Name: "Jane"}).
+		ThenReturn(nil)
+	u := &User{}
+	_ = m.Load(1, u)
+	r.AssertEqual("John", u.Name)
+	_ = m.Load(1, u)
+	r.AssertEqual("Jane", u.Name)
+	_ = m.Load(1, u)
+	r.AssertEqual("Jane", u.Name)
+	r.AssertNoError()
+}
+
+func TestCopyToArg(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenDouble(m.Read(Any[[]byte]())).
+		ThenCopyToArg(0, []byte("hello")).
+		ThenReturn(5, nil)
+	buf := make([]byte, 10)
+	n, err := m.Read(buf)
+	r.AssertEqual(5, n)
+	r.AssertEqual(nil, err)
+	r.AssertEqual("hello", string(buf[:n]))
+	r.AssertNoError()
+}
+
+func TestSetVariadicArgs(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Scan(Any[any](), Any[any]())).
+		ThenSetVariadicArgs(42, "John").
+		ThenReturn(nil)
+	var id int
+	var name string
+	err := m.Scan(&id, &name)
+	r.AssertEqual(nil, err)
+	r.AssertEqual(42, id)
+	r.AssertEqual("John", name)
+	r.AssertNoError()
+}
+
+func TestSetArgVariadicIndex(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Scan(Any[any](), Any[any]())).ThenSetArg(1, "John")
+	var id int
+	var name string
+	_ = m.Scan(&id, &name)
+	r.AssertEqual(0, id)
+	r.AssertEqual("John", name)
+	r.AssertNoError()
+}
+
+func TestSetArgNotAPointer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Load(AnyInt(), Any[*User]())).ThenSetArg(0, 1)
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "argument 0 of type int is not a pointer")
+}
+
+func TestSetArgTypeMismatch(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Load(AnyInt(), Any[*User]())).ThenSetArg(1, "John")
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "value of type string can not be assigned to argument 1 of type *setarg.User")
+}
+
+func TestSetArgIndexOutOfRange(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Scan(Any[any]())).ThenSetArg(1, "John")
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "out of range")
+}
+
+func TestCopyToArgNotBytes(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Count(AnyInt())).ThenCopyToArg(0, []byte("a"))
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "is not a byte slice")
+}
+
+func TestSetVariadicArgsNotVariadic(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Count(AnyInt())).ThenSetVariadicArgs(1)
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "is not variadic")
+}
+
+func TestSetArgInterfaceMismatchAtCall(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Decode(Any[any]())).ThenSetArg(0, User{Name: "John"})
+	var s string
+	_ = m.Decode(&s)
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "Unable to set arguments of call")
+}
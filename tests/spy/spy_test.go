@@ -0,0 +1,159 @@
+package spy
+
+import (
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/mockopts"
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Repo interface {
+	Get(id int) string
+	Sum(prefix string, values ...int) int
+	Save(id int, value string)
+}
+
+type repoImpl struct {
+	saved map[int]string
+}
+
+func (r *repoImpl) Get(id int) string {
+	return "real"
+}
+
+func (r *repoImpl) Sum(prefix string, values ...int) int {
+	result := 0
+	for _, v := range values {
+		result += v
+	}
+	return result
+}
+
+func (r *repoImpl) Save(id int, value string) {
+	r.saved[id] = value
+}
+
+func newRepo() *repoImpl {
+	return &repoImpl{saved: make(map[int]string)}
+}
+
+func TestSpyDelegatesUnstubbed(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	real := newRepo()
+	s := Spy[Repo](ctrl, real)
+	s.Save(1, "one")
+	r.AssertEqual("real", s.Get(1))
+	r.AssertEqual("one", real.saved[1])
+	r.AssertNoError()
+}
+
+func TestSpyStubbedMethod(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	s := Spy[Repo](ctrl, newRepo())
+	WhenSingle(s.Get(Exact(1))).ThenReturn("fake")
+	r.AssertEqual("fake", s.Get(1))
+	r.AssertEqual("real", s.Get(2))
+	r.AssertNoError()
+}
+
+func TestSpyMatchersDoNotCallReal(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	real := newRepo()
+	s := Spy[Repo](ctrl, real)
+	When(s.Sum(AnyString(), AnyInt())).ThenReturn(100)
+	r.AssertEqual(100, s.Sum("a", 1))
+	r.AssertNoError()
+}
+
+func TestSpyVariadic(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	s := Spy[Repo](ctrl, newRepo())
+	r.AssertEqual(6, s.Sum("a", 1, 2, 3))
+	r.AssertEqual(0, s.Sum("a"))
+	Verify(s, Once()).Sum("a", 1, 2, 3)
+	r.AssertNoError()
+}
+
+func TestSpyVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	s := Spy[Repo](ctrl, newRepo())
+	s.Get(1)
+	s.Get(2)
+	Verify(s, Times(2)).Get(AnyInt())
+	VerifyNoMoreInteractions(s)
+	r.AssertNoError()
+}
+
+func TestSpyCaptor(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	s := Spy[Repo](ctrl, newRepo())
+	s.Save(1, "one")
+	c := Captor[string]()
+	Verify(s, Once()).Save(AnyInt(), c.Capture())
+	r.AssertEqual("one", c.Last())
+	r.AssertNoError()
+}
+
+func TestSpyStrictVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r, mockopts.StrictVerify())
+	s := Spy[Repo](ctrl, newRepo())
+	s.Get(1)
+	r.TriggerCleanup()
+	r.AssertError()
+}
+
+func TestSpyNilDelegate(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic, but code did not panic")
+		}
+	}()
+	_ = Spy[Repo](ctrl, nil)
+}
+
+func TestCallRealMethod(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	s := Spy[Repo](ctrl, newRepo())
+	WhenSingle(s.Get(AnyInt())).
+		ThenReturn("fake").
+		ThenCallRealMethod().
+		ThenReturn("fake again")
+	r.AssertEqual("fake", s.Get(1))
+	r.AssertEqual("real", s.Get(1))
+	r.AssertEqual("fake again", s.Get(1))
+	r.AssertEqual("fake again", s.Get(1))
+	r.AssertNoError()
+}
+
+func TestCallRealMethodAll(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	s := Spy[Repo](ctrl, newRepo())
+	When(s.Sum(AnyString(), AnyInt(), AnyInt())).
+		ThenCallRealMethod().
+		ThenReturn(-1)
+	r.AssertEqual(3, s.Sum("a", 1, 2))
+	r.AssertEqual(-1, s.Sum("a", 1, 2))
+	r.AssertNoError()
+}
+
+func TestCallRealMethodOnMock(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[Repo](ctrl)
+	WhenSingle(m.Get(AnyInt())).ThenCallRealMethod()
+	r.AssertError()
+	r.AssertEqual("", m.Get(1))
+}
@@ -0,0 +1,131 @@
+package thendo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type User struct {
+	ID string
+}
+
+type iface interface {
+	Get(ctx context.Context, id string) (*User, error)
+	Format(prefix string, values ...int) string
+	Triple(a int) (int, string, error)
+}
+
+type Fetcher func(id string) error
+
+func TestThenDoDouble(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenDouble(m.Get(AnyContext(), AnyString())).ThenDo(func(ctx context.Context, id string) (*User, error) {
+		return &User{ID: id}, nil
+	})
+	u, err := m.Get(context.Background(), "john")
+	r.AssertNoError()
+	r.AssertEqual(nil, err)
+	r.AssertEqual("john", u.ID)
+}
+
+func TestThenDoVariadic(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Format(AnyString(), AnyInt(), AnyInt())).ThenDo(func(prefix string, values ...int) string {
+		return fmt.Sprintf("%s%v", prefix, values)
+	})
+	r.AssertEqual("a[1 2]", m.Format("a", 1, 2))
+	r.AssertNoError()
+}
+
+func TestThenDoAll(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	When(m.Triple(AnyInt())).
+		ThenDo(func(a int) (int, string, error) {
+			return a * 2, "ok", nil
+		}).
+		ThenReturn(0, "", errors.New("fail"))
+	a, b, err := m.Triple(2)
+	r.AssertEqual(4, a)
+	r.AssertEqual("ok", b)
+	r.AssertEqual(nil, err)
+	_, _, err = m.Triple(2)
+	r.AssertEqual("fail", err.Error())
+	r.AssertNoError()
+}
+
+func TestThenDoMockFunc(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	fn := MockFunc[Fetcher](ctrl)
+	WhenSingle(fn(AnyString())).ThenDo(func(id string) error {
+		return errors.New(id)
+	})
+	r.AssertEqual("a", fn("a").Error())
+	r.AssertNoError()
+}
+
+func TestThenDoRestubWithMatchers(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	calls := 0
+	WhenSingle(m.Format(AnyString())).ThenDo(func(prefix string, values ...int) string {
+		calls++
+		return prefix
+	})
+	WhenSingle(m.Format(AnyString())).ThenReturn("b")
+	r.AssertEqual(0, calls)
+	r.AssertEqual("a", m.Format("a"))
+	r.AssertEqual(1, calls)
+	r.AssertNoError()
+}
+
+func TestThenDoInvalidSignature(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenDouble(m.Get(AnyContext(), AnyString())).ThenDo(func(id string) (*User, error) {
+		return nil, nil
+	})
+	r.AssertError()
+	r.AssertErrorContains(r.GetError(), "invalid ThenDo function")
+	r.AssertErrorContains(r.GetError(), "func(string) (*thendo.User, error)")
+}
+
+func TestThenDoInvalidResults(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Format(AnyString())).ThenDo(func(prefix string, values ...int) int {
+		return 0
+	})
+	r.AssertError()
+}
+
+func TestThenDoNotAFunc(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Format(AnyString())).ThenDo(42)
+	r.AssertError()
+}
+
+func TestThenDoNil(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[iface](ctrl)
+	WhenSingle(m.Format(AnyString())).ThenDo(nil)
+	r.AssertError()
+}
@@ -108,3 +108,14 @@ func TestWhenSingleMockAsArg(t *testing.T) {
 	r.AssertEqual(true, res)
 	r.AssertNoError()
 }
+
+func TestWhenSingleRestubKeepsAnswerOrder(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[whenSingleInterface](ctrl)
+	WhenSingle(m.Foo(10)).ThenReturn(1).ThenReturn(2)
+	WhenSingle(m.Foo(10)).ThenReturn(3)
+	r.AssertEqual(1, m.Foo(10))
+	r.AssertEqual(2, m.Foo(10))
+	r.AssertNoError()
+}
@@ -0,0 +1,125 @@
+package when
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ovechkin-dm/mockio/v2/tests/common"
+
+	. "github.com/ovechkin-dm/mockio/v2/mock"
+)
+
+type Meta struct {
+	Size int
+}
+
+type WhenTripleInterface interface {
+	Get(ctx context.Context, key string) (string, bool, error)
+	ReadAt(p []byte, off int64) (int, bool, Meta, error)
+}
+
+func TestWhenTripleRet(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[WhenTripleInterface](ctrl)
+	WhenTriple(m.Get(AnyContext(), AnyString())).ThenReturn("value", true, nil)
+	v, ok, err := m.Get(context.Background(), "key")
+	r.AssertEqual("value", v)
+	r.AssertEqual(true, ok)
+	r.AssertEqual(nil, err)
+	r.AssertNoError()
+}
+
+func TestWhenTripleAnswer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[WhenTripleInterface](ctrl)
+	WhenTriple(m.Get(AnyContext(), AnyString())).ThenAnswer(func(args []any) (string, bool, error) {
+		return args[1].(string), false, errors.New("not found")
+	})
+	v, ok, err := m.Get(context.Background(), "key")
+	r.AssertEqual("key", v)
+	r.AssertEqual(false, ok)
+	r.AssertEqual("not found", err.Error())
+	r.AssertNoError()
+}
+
+func TestWhenTripleMultiReturn(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[WhenTripleInterface](ctrl)
+	WhenTriple(m.Get(AnyContext(), AnyString())).
+		ThenReturn("", false, nil).
+		ThenReturn("value", true, nil)
+	_, ok1, _ := m.Get(context.Background(), "key")
+	_, ok2, _ := m.Get(context.Background(), "key")
+	r.AssertEqual(false, ok1)
+	r.AssertEqual(true, ok2)
+	r.AssertNoError()
+}
+
+func TestWhenTripleVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[WhenTripleInterface](ctrl)
+	WhenTriple(m.Get(AnyContext(), AnyString())).ThenReturn("value", true, nil).Verify(Once())
+	_, _, _ = m.Get(context.Background(), "key")
+	_, _, _ = m.Get(context.Background(), "key")
+	VerifyNoMoreInteractions(m)
+	r.AssertError()
+}
+
+func TestWhenQuadRet(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[WhenTripleInterface](ctrl)
+	WhenQuad(m.ReadAt(Any[[]byte](), Any[int64]())).ThenReturn(10, true, Meta{Size: 10}, nil)
+	n, eof, meta, err := m.ReadAt(nil, 0)
+	r.AssertEqual(10, n)
+	r.AssertEqual(true, eof)
+	r.AssertEqual(Meta{Size: 10}, meta)
+	r.AssertEqual(nil, err)
+	r.AssertNoError()
+}
+
+func TestWhenQuadAnswer(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[WhenTripleInterface](ctrl)
+	WhenQuad(m.ReadAt(Any[[]byte](), Any[int64]())).
+		ThenCopyToArg(0, []byte("abc")).
+		ThenAnswer(func(args []any) (int, bool, Meta, error) {
+			return 3, false, Meta{Size: int(args[1].(int64))}, nil
+		})
+	buf := make([]byte, 3)
+	n, eof, meta, err := m.ReadAt(buf, 5)
+	r.AssertEqual(3, n)
+	r.AssertEqual(false, eof)
+	r.AssertEqual(Meta{Size: 5}, meta)
+	r.AssertEqual(nil, err)
+	r.AssertEqual("abc", string(buf))
+	r.AssertNoError()
+}
+
+func TestWhenQuadVerify(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[WhenTripleInterface](ctrl)
+	WhenQuad(m.ReadAt(Any[[]byte](), Any[int64]())).ThenReturn(0, true, Meta{}, nil).Verify(Once())
+	_, _, _, _ = m.ReadAt(nil, 0)
+	VerifyNoMoreInteractions(m)
+	r.AssertNoError()
+}
+
+func TestWhenQuadPanic(t *testing.T) {
+	r := common.NewMockReporter(t)
+	ctrl := NewMockController(r)
+	m := Mock[WhenTripleInterface](ctrl)
+	WhenQuad(m.ReadAt(Any[[]byte](), Any[int64]())).ThenPanic("boom")
+	defer func() {
+		r.AssertEqual("boom", recover())
+		r.AssertNoError()
+	}()
+	_, _, _, _ = m.ReadAt(nil, 0)
+}
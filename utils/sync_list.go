@@ -21,6 +21,20 @@ func (l *SyncList[T]) GetCopy() []T {
 	return itemsCopy
 }
 
+func (l *SyncList[T]) Clear() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.items = make([]T, 0)
+}
+
+func (l *SyncList[T]) Update(f func(items []T) []T) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	itemsCopy := make([]T, len(l.items))
+	copy(itemsCopy, l.items)
+	l.items = f(itemsCopy)
+}
+
 func NewSyncList[T any]() *SyncList[T] {
 	return &SyncList[T]{
 		lock:  sync.Mutex{},